@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"github.com/rollicks-c/term/internal/datetime"
 	"github.com/rollicks-c/term/internal/num"
+	"github.com/rollicks-c/term/io"
+	"os"
 	"time"
 )
 
@@ -22,6 +24,7 @@ type Collector struct {
 	dateFormat     string
 	timeFormat     string
 	dateTimeFormat string
+	io             *io.Module
 }
 
 type ListProvider interface {
@@ -54,6 +57,12 @@ func WithDateTimeLayout(layout string) CollectorOption {
 	}
 }
 
+func WithIO(m *io.Module) CollectorOption {
+	return func(c *Collector) {
+		c.io = m
+	}
+}
+
 func WithDefault[T any](defaultValue T) ArgOption[T] {
 	return func(ctx *ArgContext[T]) {
 		ctx.defaultValue = &defaultValue
@@ -73,6 +82,13 @@ func NewCollector(args []string, options ...CollectorOption) *Collector {
 	return ac
 }
 
+func (c Collector) module() *io.Module {
+	if c.io == nil {
+		return io.New(os.Stdin, os.Stdout)
+	}
+	return c.io
+}
+
 func (c Collector) Count() int {
 	return len(c.args)
 }
@@ -221,10 +237,20 @@ func (c Collector) GetString(index int, options ...ArgOption[string]) (string, e
 }
 
 func (c Collector) GetListItem(index int, provider ListProvider, options ...ArgOption[any]) (any, error) {
-	options = append(options, withParsers(itemSelector(provider)))
+	options = append(options, withParsers(itemSelector(c.module(), index, provider)))
 	return retrieve[any](c.args, index, options...)
 }
 
+func FindItem(m *io.Module, label string, provider ListProvider, options ...io.FinderOption) (any, error) {
+	return m.Find(label, searchItems(provider), options...)
+}
+
+func WithItemPreview(preview func(item ListItem) string) io.FinderOption {
+	return io.WithPreview(func(item io.FinderItem) string {
+		return preview(ListItem{Value: item.Value, Name: item.Label})
+	})
+}
+
 func (c Collector) GetDate(index int, options ...ArgOption[time.Time]) (time.Time, error) {
 	dateRelParser := datetime.NewParser().ParseRelativeDay
 	dateAbsParser := datetime.NewParser().DateParser(c.dateFormat)
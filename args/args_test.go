@@ -1,7 +1,10 @@
 package args
 
 import (
+	"bytes"
+	"github.com/rollicks-c/term/io"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 	"time"
 )
@@ -19,3 +22,63 @@ func TestDefaults(t *testing.T) {
 	assert.Equal(t, now, act)
 
 }
+
+func TestRankItems(t *testing.T) {
+
+	items := []ListItem{{Name: "sub-api"}, {Name: "api-gateway"}, {Name: "web"}, {Name: "API"}, {Name: "api-docs"}}
+	ranked := rankItems("api", items)
+
+	names := make([]string, len(ranked))
+	for i, item := range ranked {
+		names[i] = item.Name
+	}
+	assert.Equal(t, []string{"API", "api-gateway", "api-docs", "sub-api", "web"}, names)
+
+}
+
+type projectList []ListItem
+
+func (p projectList) SearchItems(exp string) ([]ListItem, error) {
+	found := make([]ListItem, 0)
+	for _, item := range p {
+		if strings.Contains(item.Name, exp) {
+			found = append(found, item)
+		}
+	}
+	return found, nil
+}
+
+func (p projectList) ListItems() ([]ListItem, error) {
+	return p, nil
+}
+
+func TestGetListItem(t *testing.T) {
+
+	projects := projectList{{Name: "sub-api", Value: 1}, {Name: "api", Value: 2}, {Name: "web", Value: 3}}
+	out := &bytes.Buffer{}
+
+	// ambiguous matches are offered ranked through the given module
+	ac := NewCollector([]string{"api"}, WithIO(io.New(strings.NewReader("2\n"), out)))
+	value, err := ac.GetListItem(0, projects)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, value)
+	assert.Contains(t, out.String(), "1) api\n  2) sub-api")
+
+	// every arg is answered under its own key
+	recorder := io.NewRecorder()
+	ac = NewCollector([]string{"api", "api"}, WithIO(io.New(strings.NewReader("2\n1\n"), out, io.WithRecorder(recorder))))
+	value, err = ac.GetListItem(0, projects)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, value)
+	value, err = ac.GetListItem(1, projects)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, value)
+	assert.Equal(t, io.Answers{"arg_0": "sub-api", "arg_1": "api"}, recorder.Answers())
+
+	// answered up front
+	m := io.New(strings.NewReader(""), out, io.WithAnswers(io.Answers{"project": "web"}))
+	value, err = FindItem(m, "project", projects)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, value)
+
+}
@@ -3,7 +3,8 @@ package args
 import (
 	"fmt"
 	"github.com/rollicks-c/term/io"
-	"os"
+	"slices"
+	"strings"
 )
 
 func withParsers[T any](parsers ...argParser[T]) ArgOption[T] {
@@ -16,7 +17,7 @@ func parseString(exp string) (string, error) {
 	return exp, nil
 }
 
-func itemSelector(provider ListProvider) argParser[any] {
+func itemSelector(m *io.Module, index int, provider ListProvider) argParser[any] {
 	return func(exp string) (any, error) {
 
 		// filter
@@ -31,16 +32,54 @@ func itemSelector(provider ListProvider) argParser[any] {
 			return selection[0].Value, nil
 		}
 
-		// choose
-		list := make(map[string]any)
-		for _, item := range selection {
-			list[item.Name] = item.Value
+		// narrow down in the finder, best matches first, answered per arg
+		key := io.WithFinderKey(fmt.Sprintf("arg_%d", index))
+		return m.Find("choose exact item:", searchItems(provider), io.WithQuery(exp), key)
+	}
+}
+
+func searchItems(provider ListProvider) io.SearchFunc {
+	return func(query string) ([]io.FinderItem, error) {
+
+		// everything until something is typed
+		search := provider.SearchItems
+		if query == "" {
+			search = func(string) ([]ListItem, error) {
+				return provider.ListItems()
+			}
 		}
-		sel, err := io.New(os.Stdin, os.Stdout).Choose("choose exact item:", list)
+		items, err := search(query)
 		if err != nil {
 			return nil, err
 		}
 
-		return sel, nil
+		found := make([]io.FinderItem, len(items))
+		for i, item := range rankItems(query, items) {
+			found[i] = io.FinderItem{Label: item.Name, Value: item.Value}
+		}
+		return found, nil
+	}
+}
+
+func rankItems(exp string, items []ListItem) []ListItem {
+
+	// exact, prefix, contains, rest - provider order within each rank
+	exp = strings.ToLower(exp)
+	rank := func(item ListItem) int {
+		name := strings.ToLower(item.Name)
+		switch {
+		case name == exp:
+			return 0
+		case strings.HasPrefix(name, exp):
+			return 1
+		case strings.Contains(name, exp):
+			return 2
+		}
+		return 3
 	}
+	ranked := slices.Clone(items)
+	slices.SortStableFunc(ranked, func(a, b ListItem) int {
+		return rank(a) - rank(b)
+	})
+	return ranked
 }
@@ -5,14 +5,14 @@ go 1.23.0
 toolchain go1.23.4
 
 require (
+	github.com/chzyer/readline v1.5.1
 	github.com/manifoldco/promptui v0.9.0
 	github.com/stretchr/testify v1.10.0
+	golang.org/x/sys v0.33.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
-	github.com/chzyer/readline v1.5.1 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	golang.org/x/sys v0.33.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -4,12 +4,31 @@ import (
 	"github.com/rollicks-c/term/args"
 	"github.com/rollicks-c/term/io"
 	"os"
+	"sync"
+)
+
+// stdio is shared so buffered input and answer sources survive across prompts
+var (
+	stdio     *io.Module
+	stdioLock sync.Mutex
 )
 
 func NewArgsCollector(argList []string, options ...args.CollectorOption) *args.Collector {
+	options = append([]args.CollectorOption{args.WithIO(IO())}, options...)
 	return args.NewCollector(argList, options...)
 }
 
+func SetupIO(options ...io.Option) {
+	stdioLock.Lock()
+	defer stdioLock.Unlock()
+	stdio = io.New(os.Stdin, os.Stdout, options...)
+}
+
 func IO() *io.Module {
-	return io.New(os.Stdin, os.Stdout)
+	stdioLock.Lock()
+	defer stdioLock.Unlock()
+	if stdio == nil {
+		stdio = io.New(os.Stdin, os.Stdout)
+	}
+	return stdio
 }
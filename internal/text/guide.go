@@ -0,0 +1,20 @@
+package text
+
+type TreeGuides struct {
+	Branch string
+	Last   string
+	Pipe   string
+	Space  string
+}
+
+var (
+	unicodeTreeGuides = TreeGuides{Branch: "├── ", Last: "└── ", Pipe: "│   ", Space: "    "}
+	asciiTreeGuides   = TreeGuides{Branch: "|-- ", Last: "`-- ", Pipe: "|   ", Space: "    "}
+)
+
+func Guides(ascii bool) TreeGuides {
+	if ascii {
+		return asciiTreeGuides
+	}
+	return unicodeTreeGuides
+}
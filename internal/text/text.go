@@ -0,0 +1,193 @@
+package text
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const reset = "\033[0m"
+
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+func Strip(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+func Width(s string) int {
+	width := 0
+	for _, r := range Strip(s) {
+		width += RuneWidth(r)
+	}
+	return width
+}
+
+func RuneWidth(r rune) int {
+
+	// invisible
+	if r == 0 || unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) || unicode.Is(unicode.Cf, r) {
+		return 0
+	}
+
+	// east asian wide and emoji
+	switch {
+	case r >= 0x1100 && r <= 0x115F,
+		r >= 0x2E80 && r <= 0xA4CF,
+		r >= 0xAC00 && r <= 0xD7A3,
+		r >= 0xF900 && r <= 0xFAFF,
+		r >= 0xFE30 && r <= 0xFE4F,
+		r >= 0xFF00 && r <= 0xFF60,
+		r >= 0xFFE0 && r <= 0xFFE6,
+		r >= 0x1F300 && r <= 0x1F64F,
+		r >= 0x1F900 && r <= 0x1F9FF,
+		r >= 0x20000 && r <= 0x3FFFD:
+		return 2
+	}
+
+	return 1
+}
+
+func Truncate(s string, width int) string {
+
+	// fits already
+	if Width(s) <= width {
+		return s
+	}
+
+	// copy runes and escape sequences until width is exhausted
+	out := strings.Builder{}
+	styled := false
+	used := 0
+	for len(s) > 0 {
+		if n := escapeLen(s); n > 0 {
+			out.WriteString(s[:n])
+			s = s[n:]
+			styled = true
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s)
+		if used+RuneWidth(r) > width {
+			break
+		}
+		out.WriteRune(r)
+		used += RuneWidth(r)
+		s = s[size:]
+	}
+	if styled {
+		out.WriteString(reset)
+	}
+
+	return out.String()
+}
+
+func Pad(s string, width int) string {
+	missing := width - Width(s)
+	if missing <= 0 {
+		return s
+	}
+	return s + strings.Repeat(" ", missing)
+}
+
+func PadLeft(s string, width int) string {
+	missing := width - Width(s)
+	if missing <= 0 {
+		return s
+	}
+	return strings.Repeat(" ", missing) + s
+}
+
+func Wrap(s string, width int) []string {
+
+	// no limit
+	if width <= 0 {
+		return strings.Split(s, "\n")
+	}
+
+	// wrap each paragraph on word boundaries
+	lines := make([]string, 0)
+	for _, paragraph := range strings.Split(s, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+
+			// split words longer than the line
+			for Width(word) > width {
+				if line != "" {
+					lines = append(lines, line)
+					line = ""
+				}
+				head, tail := splitAt(word, width)
+				lines = append(lines, head)
+				word = tail
+			}
+
+			// append word
+			switch {
+			case line == "":
+				line = word
+			case Width(line)+1+Width(word) <= width:
+				line += " " + word
+			default:
+				lines = append(lines, line)
+				line = word
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func ExpandTabs(s string, tabWidth int) string {
+	if !strings.Contains(s, "\t") {
+		return s
+	}
+	out := strings.Builder{}
+	col := 0
+	for len(s) > 0 {
+		if n := escapeLen(s); n > 0 {
+			out.WriteString(s[:n])
+			s = s[n:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s)
+		s = s[size:]
+		if r == '\t' {
+			spaces := tabWidth - col%tabWidth
+			out.WriteString(strings.Repeat(" ", spaces))
+			col += spaces
+			continue
+		}
+		out.WriteRune(r)
+		col += RuneWidth(r)
+	}
+	return out.String()
+}
+
+func escapeLen(s string) int {
+	if s[0] != 0x1b {
+		return 0
+	}
+	loc := ansiPattern.FindStringIndex(s)
+	if loc == nil || loc[0] != 0 {
+		return 0
+	}
+	return loc[1]
+}
+
+func splitAt(s string, width int) (string, string) {
+	used := 0
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if used > 0 && used+RuneWidth(r) > width {
+			return s[:i], s[i:]
+		}
+		used += RuneWidth(r)
+		i += size
+	}
+	return s, ""
+}
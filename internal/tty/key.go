@@ -0,0 +1,116 @@
+package tty
+
+import "unicode/utf8"
+
+type KeyCode int
+
+const (
+	KeyRune KeyCode = iota
+	KeyEnter
+	KeyEsc
+	KeyBackspace
+	KeyTab
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyHome
+	KeyEnd
+	KeyPgUp
+	KeyPgDn
+	KeyDelete
+	KeyCtrlA
+	KeyCtrlC
+	KeyCtrlD
+	KeyUnknown
+)
+
+type Key struct {
+	Code KeyCode
+	Rune rune
+}
+
+var sequences = map[string]KeyCode{
+	"[A":  KeyUp,
+	"[B":  KeyDown,
+	"[C":  KeyRight,
+	"[D":  KeyLeft,
+	"[H":  KeyHome,
+	"[F":  KeyEnd,
+	"OA":  KeyUp,
+	"OB":  KeyDown,
+	"OC":  KeyRight,
+	"OD":  KeyLeft,
+	"OH":  KeyHome,
+	"OF":  KeyEnd,
+	"[1~": KeyHome,
+	"[7~": KeyHome,
+	"[4~": KeyEnd,
+	"[8~": KeyEnd,
+	"[3~": KeyDelete,
+	"[5~": KeyPgUp,
+	"[6~": KeyPgDn,
+	"[Z":  KeyTab,
+}
+
+func Decode(b []byte) []Key {
+	keys := make([]Key, 0, len(b))
+	for len(b) > 0 {
+		key, size := decodeOne(b)
+		keys = append(keys, key)
+		b = b[size:]
+	}
+	return keys
+}
+
+func decodeOne(b []byte) (Key, int) {
+
+	// control characters
+	switch b[0] {
+	case '\r', '\n':
+		return Key{Code: KeyEnter}, 1
+	case '\t':
+		return Key{Code: KeyTab}, 1
+	case 127, 8:
+		return Key{Code: KeyBackspace}, 1
+	case 1:
+		return Key{Code: KeyCtrlA}, 1
+	case 3:
+		return Key{Code: KeyCtrlC}, 1
+	case 4:
+		return Key{Code: KeyCtrlD}, 1
+	case 27:
+		return decodeEscape(b)
+	}
+	if b[0] < 32 {
+		return Key{Code: KeyUnknown}, 1
+	}
+
+	// printable
+	r, size := utf8.DecodeRune(b)
+	return Key{Code: KeyRune, Rune: r}, size
+}
+
+func decodeEscape(b []byte) (Key, int) {
+
+	// lone escape
+	if len(b) == 1 || (b[1] != '[' && b[1] != 'O') {
+		return Key{Code: KeyEsc}, 1
+	}
+
+	// find end of sequence
+	end := 2
+	for end < len(b) && (b[end] < 0x40 || b[end] > 0x7e) {
+		end++
+	}
+	if end == len(b) {
+		return Key{Code: KeyUnknown}, len(b)
+	}
+
+	// map sequence
+	code, ok := sequences[string(b[1:end+1])]
+	if !ok {
+		return Key{Code: KeyUnknown}, end + 1
+	}
+	return Key{Code: code}, end + 1
+}
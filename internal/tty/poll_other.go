@@ -0,0 +1,14 @@
+//go:build !unix
+
+package tty
+
+import (
+	"os"
+	"time"
+)
+
+// without polling reads block, callers never see a timeout and
+// ReadKeyTimeout behaves like ReadKey
+func waitInput(_ *os.File, _ time.Duration) (bool, error) {
+	return true, nil
+}
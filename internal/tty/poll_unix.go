@@ -0,0 +1,26 @@
+//go:build unix
+
+package tty
+
+import (
+	"golang.org/x/sys/unix"
+	"os"
+	"time"
+)
+
+func waitInput(f *os.File, timeout time.Duration) (bool, error) {
+
+	// negative timeouts block until input arrives
+	millis := -1
+	if timeout >= 0 {
+		millis = int(timeout.Milliseconds())
+	}
+	fds := []unix.PollFd{{Fd: int32(f.Fd()), Events: unix.POLLIN}}
+	for {
+		n, err := unix.Poll(fds, millis)
+		if err == unix.EINTR {
+			continue
+		}
+		return n > 0, err
+	}
+}
@@ -0,0 +1,35 @@
+//go:build unix
+
+package tty
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestWaitInput(t *testing.T) {
+	r, w, err := os.Pipe()
+	assert.NoError(t, err)
+	defer func() {
+		_ = r.Close()
+		_ = w.Close()
+	}()
+
+	// zero timeout returns at once without input
+	ready, err := waitInput(r, 0)
+	assert.NoError(t, err)
+	assert.False(t, ready)
+
+	// negative timeout blocks until input arrives
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_, _ = w.Write([]byte("x"))
+	}()
+	start := time.Now()
+	ready, err = waitInput(r, -1)
+	assert.NoError(t, err)
+	assert.True(t, ready)
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+}
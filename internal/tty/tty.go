@@ -0,0 +1,166 @@
+package tty
+
+import (
+	"fmt"
+	"github.com/chzyer/readline"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	altScreenOn  = "\033[?1049h"
+	altScreenOff = "\033[?1049l"
+	cursorHide   = "\033[?25l"
+	cursorShow   = "\033[?25h"
+	cursorHome   = "\033[H"
+	clearLine    = "\033[K"
+	clearBelow   = "\033[J"
+)
+
+type Terminal struct {
+	in      *os.File
+	out     io.Writer
+	state   *readline.State
+	pending []Key
+	signals chan os.Signal
+	done    chan struct{}
+}
+
+func IsTerminal(v any) bool {
+	f, ok := v.(*os.File)
+	if !ok {
+		return false
+	}
+	return readline.IsTerminal(int(f.Fd()))
+}
+
+func Size(v any) (int, int, bool) {
+	f, ok := v.(*os.File)
+	if !ok {
+		return 0, 0, false
+	}
+	width, height, err := readline.GetSize(int(f.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return 0, 0, false
+	}
+	return width, height, true
+}
+
+func Open(in io.Reader, out io.Writer) (*Terminal, error) {
+
+	// require a terminal
+	f, ok := in.(*os.File)
+	if !ok || !IsTerminal(f) {
+		return nil, fmt.Errorf("input is not a terminal")
+	}
+
+	// enter raw mode
+	state, err := readline.MakeRaw(int(f.Fd()))
+	if err != nil {
+		return nil, err
+	}
+	t := &Terminal{
+		in:      f,
+		out:     out,
+		state:   state,
+		signals: make(chan os.Signal, 1),
+		done:    make(chan struct{}),
+	}
+
+	// restore terminal when killed
+	signal.Notify(t.signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-t.signals:
+			_ = t.Close()
+			os.Exit(1)
+		case <-t.done:
+		}
+	}()
+
+	// switch to alternate screen
+	if _, err := fmt.Fprint(out, altScreenOn+cursorHide); err != nil {
+		_ = t.Close()
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (t *Terminal) Close() error {
+	select {
+	case <-t.done:
+		return nil
+	default:
+		close(t.done)
+	}
+	signal.Stop(t.signals)
+	_, _ = fmt.Fprint(t.out, cursorShow+altScreenOff)
+	return readline.Restore(int(t.in.Fd()), t.state)
+}
+
+func (t *Terminal) Size() (int, int) {
+	if width, height, ok := Size(t.out); ok {
+		return width, height
+	}
+	if width, height, ok := Size(t.in); ok {
+		return width, height
+	}
+	return 80, 24
+}
+
+func (t *Terminal) ReadKey() (Key, error) {
+
+	// keys left from previous read
+	if len(t.pending) > 0 {
+		key := t.pending[0]
+		t.pending = t.pending[1:]
+		return key, nil
+	}
+
+	// read next chunk
+	buf := make([]byte, 256)
+	n, err := t.in.Read(buf)
+	if err != nil {
+		return Key{}, err
+	}
+	if n == 0 {
+		return Key{Code: KeyUnknown}, nil
+	}
+	keys := Decode(buf[:n])
+	t.pending = keys[1:]
+
+	return keys[0], nil
+}
+
+func (t *Terminal) ReadKeyTimeout(timeout time.Duration) (Key, bool, error) {
+
+	// wait for input unless keys are left
+	if len(t.pending) == 0 {
+		ready, err := waitInput(t.in, timeout)
+		if err != nil || !ready {
+			return Key{}, false, err
+		}
+	}
+	key, err := t.ReadKey()
+	return key, err == nil, err
+}
+
+func (t *Terminal) Draw(lines []string) error {
+	out := strings.Builder{}
+	out.WriteString(cursorHome)
+	for i, line := range lines {
+		out.WriteString(line)
+		out.WriteString(clearLine)
+		if i < len(lines)-1 {
+			out.WriteString("\r\n")
+		}
+	}
+	out.WriteString(clearBelow)
+	_, err := fmt.Fprint(t.out, out.String())
+	return err
+}
@@ -0,0 +1,158 @@
+package io
+
+import (
+	"errors"
+	"fmt"
+	"github.com/rollicks-c/term/internal/text"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const DefaultEnvPrefix = "APP"
+
+type InputPolicy int
+
+const (
+	AskAlways InputPolicy = iota
+	UseDefaults
+	FailMissing
+)
+
+type AnswerSource interface {
+	Answer(key string) (string, bool)
+}
+
+type Answers map[string]string
+
+type envAnswers struct {
+	prefix string
+}
+
+type MissingInputError struct {
+	Key string
+}
+
+var (
+	keyPattern = regexp.MustCompile(`[^a-z0-9]+`)
+	envPattern = regexp.MustCompile(`[^A-Z0-9_]`)
+)
+
+func (e MissingInputError) Error() string {
+	return fmt.Sprintf("missing input %s", e.Key)
+}
+
+func WithInputPolicy(policy InputPolicy) Option {
+	return func(m *Module) {
+		m.policy = policy
+		m.policySet = true
+	}
+}
+
+func WithAnswers(sources ...AnswerSource) Option {
+	return func(m *Module) {
+		m.answers = append(m.answers, sources...)
+	}
+}
+
+func WithAssumeYes(state bool) Option {
+	return func(m *Module) {
+		m.assumeYes = state
+	}
+}
+
+func EnvAnswers(prefix string) AnswerSource {
+	return envAnswers{prefix: prefix}
+}
+
+func LoadAnswers(path string) (Answers, error) {
+
+	// yaml covers json as well
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[string]any)
+	if err := yaml.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("invalid answers file %s: %w", path, err)
+	}
+
+	answers := make(Answers, len(data))
+	for key, value := range data {
+		answers[key] = fmt.Sprintf("%v", value)
+	}
+	return answers, nil
+}
+
+func (a Answers) Answer(key string) (string, bool) {
+	value, ok := a[key]
+	return value, ok
+}
+
+func (e envAnswers) Answer(key string) (string, bool) {
+
+	// shells only export [A-Z0-9_], so "db.host-name" reads <PREFIX>_ANSWER_DB_HOST_NAME
+	name := fmt.Sprintf("%s_ANSWER_%s", e.prefix, envPattern.ReplaceAllString(strings.ToUpper(key), "_"))
+	return os.LookupEnv(name)
+}
+
+func (m Module) exhausted(q Question, err error) (string, error) {
+
+	// input ran out, valid defaults answer the rest unless a policy was chosen
+	if !errors.Is(err, io.EOF) || m.policySet {
+		return "", err
+	}
+	if q.Validate != nil && q.Validate(q.Default) != nil {
+		return "", MissingInputError{Key: q.key()}
+	}
+	return q.Default, nil
+}
+
+func (m Module) answer(key string) (string, bool) {
+	for _, source := range m.answers {
+		if value, ok := source.Answer(key); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func (m Module) resolve(q Question) (string, bool, error) {
+
+	// answered up front
+	key := q.key()
+	if value, ok := m.answer(key); ok {
+		if q.Validate != nil {
+			if err := q.Validate(value); err != nil {
+				return "", false, fmt.Errorf("invalid answer for %s: %w", key, err)
+			}
+		}
+		return value, true, nil
+	}
+	m.miss(key)
+
+	// ask the user
+	if m.policy == AskAlways {
+		return "", false, nil
+	}
+
+	// fall back to a valid default
+	if m.policy == UseDefaults && (q.Validate == nil || q.Validate(q.Default) == nil) {
+		return q.Default, true, nil
+	}
+	return "", false, MissingInputError{Key: key}
+}
+
+func (q Question) key() string {
+	if q.Key != "" {
+		return q.Key
+	}
+	return promptKey(q.Label)
+}
+
+func promptKey(label string) string {
+	key := strings.ToLower(text.Strip(label))
+	return strings.Trim(keyPattern.ReplaceAllString(key, "_"), "_")
+}
@@ -0,0 +1,94 @@
+package io
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAnswerSources(t *testing.T) {
+	t.Setenv("APP_ANSWER_PROJECT_NAME", "from-env")
+	path := filepath.Join(t.TempDir(), "answers.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("project_name: from-file\ncount: 3\nenv: b\n"), 0o600))
+	answers, err := LoadAnswers(path)
+	assert.NoError(t, err)
+
+	m := New(strings.NewReader(""), &bytes.Buffer{}, WithAnswers(EnvAnswers(DefaultEnvPrefix), answers))
+
+	// env wins over file, keys are derived from labels
+	name, err := m.PromptString("Project name")
+	assert.NoError(t, err)
+	assert.Equal(t, "from-env", name)
+
+	// env names replace characters shells cannot export
+	t.Setenv("APP_ANSWER_DB_HOST_NAME", "db1")
+	host, err := m.Ask(Question{Key: "db.host-name", Label: "Host"})
+	assert.NoError(t, err)
+	assert.Equal(t, "db1", host)
+
+	// explicit keys
+	count, err := m.Ask(Question{Key: "count", Label: "How many?"})
+	assert.NoError(t, err)
+	assert.Equal(t, "3", count)
+
+	// selections by label
+	choice, err := m.Choose("env", map[string]any{"a": 1, "b": 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, choice)
+}
+
+func TestInputPolicy(t *testing.T) {
+
+	// defaults
+	m := New(strings.NewReader(""), &bytes.Buffer{}, WithInputPolicy(UseDefaults))
+	value, err := m.PromptString("name", WithDefault("Bob"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Bob", value)
+	_, err = m.PromptString("name", WithRequired())
+	assert.Equal(t, MissingInputError{Key: "name"}, err)
+	assert.False(t, m.Confirm("delete all?"))
+
+	// fail
+	m = New(strings.NewReader(""), &bytes.Buffer{}, WithInputPolicy(FailMissing))
+	_, err = m.PromptString("API token", WithDefault("x"))
+	assert.EqualError(t, err, "missing input api_token")
+	var missing MissingInputError
+	assert.True(t, errors.As(err, &missing))
+	_, err = m.ConfirmE("delete all?")
+	assert.Equal(t, MissingInputError{Key: "delete_all"}, err)
+	out := &bytes.Buffer{}
+	m = New(strings.NewReader(""), out, WithInputPolicy(FailMissing))
+	assert.False(t, m.Confirm("delete all?"))
+	assert.Contains(t, out.String(), "missing input delete_all")
+
+	// assume yes
+	m = New(strings.NewReader(""), &bytes.Buffer{}, WithInputPolicy(FailMissing), WithAssumeYes(true))
+	assert.True(t, m.Confirm("delete all?"))
+
+	// piped answers are read, defaults take over once input runs out
+	r, w, err := os.Pipe()
+	assert.NoError(t, err)
+	_, err = w.WriteString("y\n")
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	defer func() {
+		_ = r.Close()
+	}()
+	m = New(r, &bytes.Buffer{})
+	assert.True(t, m.Confirm("delete all?"))
+	value, err = m.PromptString("name", WithDefault("Bob"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Bob", value)
+	_, err = m.PromptString("name", WithRequired())
+	assert.Equal(t, MissingInputError{Key: "name"}, err)
+
+	// unless a policy was chosen
+	m = New(strings.NewReader(""), &bytes.Buffer{}, WithInputPolicy(AskAlways))
+	_, err = m.PromptString("name", WithDefault("Bob"))
+	assert.ErrorIs(t, err, io.EOF)
+}
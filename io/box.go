@@ -0,0 +1,200 @@
+package io
+
+import (
+	"fmt"
+	"github.com/rollicks-c/term/internal/text"
+	"github.com/rollicks-c/term/internal/tty"
+	"strings"
+)
+
+type BoxOption func(config *boxConfig)
+
+type BorderStyle struct {
+	Horizontal  string
+	Vertical    string
+	TopLeft     string
+	TopRight    string
+	BottomLeft  string
+	BottomRight string
+}
+
+type CalloutKind int
+
+const (
+	CalloutNote CalloutKind = iota
+	CalloutWarning
+	CalloutError
+	CalloutSuccess
+)
+
+var (
+	RoundedBorder = BorderStyle{"─", "│", "╭", "╮", "╰", "╯"}
+	SingleBorder  = BorderStyle{"─", "│", "┌", "┐", "└", "┘"}
+	DoubleBorder  = BorderStyle{"═", "║", "╔", "╗", "╚", "╝"}
+	ASCIIBorder   = BorderStyle{"-", "|", "+", "+", "+", "+"}
+)
+
+// colors are resolved late so callouts follow reassigned roles
+var callouts = map[CalloutKind]struct {
+	icon  string
+	title string
+	color func() ColPrint
+}{
+	CalloutNote:    {"ℹ", "Note", func() ColPrint { return Info }},
+	CalloutWarning: {"⚠", "Warning", func() ColPrint { return Warn }},
+	CalloutError:   {"✖", "Error", func() ColPrint { return Fatal }},
+	CalloutSuccess: {"✔", "Success", func() ColPrint { return Success }},
+}
+
+type boxConfig struct {
+	title    string
+	paddingX int
+	paddingY int
+	maxWidth int
+	border   ColPrint
+	style    BorderStyle
+}
+
+func WithBoxTitle(title string) BoxOption {
+	return func(config *boxConfig) {
+		config.title = title
+	}
+}
+
+func WithPadding(horizontal, vertical int) BoxOption {
+	return func(config *boxConfig) {
+		config.paddingX = horizontal
+		config.paddingY = vertical
+	}
+}
+
+func WithMaxWidth(width int) BoxOption {
+	return func(config *boxConfig) {
+		config.maxWidth = width
+	}
+}
+
+func WithBorderColor(col ColPrint) BoxOption {
+	return func(config *boxConfig) {
+		config.border = col
+	}
+}
+
+func WithBorderStyle(style BorderStyle) BoxOption {
+	return func(config *boxConfig) {
+		config.style = style
+	}
+}
+
+func (m Module) Box(content string, options ...BoxOption) {
+	if _, err := fmt.Fprintln(m.out, m.SBox(content, options...)); err != nil {
+		fmt.Print(err)
+	}
+}
+
+func (m Module) SBox(content string, options ...BoxOption) string {
+
+	// init config, boxes never exceed the terminal
+	config := boxConfig{
+		paddingX: 1,
+		border:   Default,
+		style:    RoundedBorder,
+	}
+	if width, _, ok := tty.Size(m.out); ok {
+		config.maxWidth = width
+	}
+	for _, opt := range options {
+		opt(&config)
+	}
+
+	return renderBox(content, config)
+}
+
+func (m Module) Callout(kind CalloutKind, format string, v ...interface{}) {
+	msg := fmt.Sprintf(format, v...)
+	m.Box(msg, calloutOptions(kind)...)
+}
+
+func (m Module) SCallout(kind CalloutKind, format string, v ...interface{}) string {
+	msg := fmt.Sprintf(format, v...)
+	return m.SBox(msg, calloutOptions(kind)...)
+}
+
+func (m Module) NoteBoxF(format string, v ...interface{}) {
+	m.Callout(CalloutNote, format, v...)
+}
+
+func (m Module) WarnBoxF(format string, v ...interface{}) {
+	m.Callout(CalloutWarning, format, v...)
+}
+
+func (m Module) ErrorBoxF(format string, v ...interface{}) {
+	m.Callout(CalloutError, format, v...)
+}
+
+func (m Module) SuccessBoxF(format string, v ...interface{}) {
+	m.Callout(CalloutSuccess, format, v...)
+}
+
+func calloutOptions(kind CalloutKind) []BoxOption {
+	callout := callouts[kind]
+	return []BoxOption{
+		WithBoxTitle(fmt.Sprintf("%s %s", callout.icon, callout.title)),
+		WithBorderColor(callout.color()),
+	}
+}
+
+func renderBox(content string, config boxConfig) string {
+
+	// wrap content into the available width
+	frame := 2 + 2*config.paddingX
+	wrapAt := 0
+	if config.maxWidth > 0 {
+		wrapAt = max(config.maxWidth-frame, 1)
+	}
+	lines := text.Wrap(strings.TrimRight(content, "\n"), wrapAt)
+
+	// inner width fits the widest line and the title
+	title := ""
+	if config.title != "" {
+		title = " " + config.title + " "
+	}
+	inner := 0
+	for _, line := range lines {
+		inner = max(inner, text.Width(line))
+	}
+	inner += 2 * config.paddingX
+	inner = max(inner, text.Width(title)+2)
+	if config.maxWidth > 0 {
+		inner = min(inner, max(config.maxWidth-2, 1))
+	}
+
+	// top border with title
+	s := config.style
+	border := config.border
+	title = text.Truncate(title, max(inner-2, 0))
+	top := s.TopLeft + s.Horizontal + title + strings.Repeat(s.Horizontal, max(inner-1-text.Width(title), 0)) + s.TopRight
+	if title == "" {
+		top = s.TopLeft + strings.Repeat(s.Horizontal, inner) + s.TopRight
+	}
+	out := []string{border(top)}
+
+	// content with padding
+	padding := strings.Repeat(" ", config.paddingX)
+	blank := border(s.Vertical) + strings.Repeat(" ", inner) + border(s.Vertical)
+	for i := 0; i < config.paddingY; i++ {
+		out = append(out, blank)
+	}
+	for _, line := range lines {
+		body := text.Pad(padding+line, inner)
+		out = append(out, border(s.Vertical)+body+border(s.Vertical))
+	}
+	for i := 0; i < config.paddingY; i++ {
+		out = append(out, blank)
+	}
+
+	// bottom border
+	out = append(out, border(s.BottomLeft+strings.Repeat(s.Horizontal, inner)+s.BottomRight))
+
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,39 @@
+package io
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestBox(t *testing.T) {
+	m := New(&bytes.Buffer{}, &bytes.Buffer{})
+
+	// title and padding
+	exp := strings.Join([]string{
+		"╭─ Title ─────╮",
+		"│             │",
+		"│  some text  │",
+		"│             │",
+		"╰─────────────╯",
+	}, "\n")
+	assert.Equal(t, exp, m.SBox("some text", WithBoxTitle("Title"), WithPadding(2, 1)))
+
+	// word wrap within width limit
+	exp = strings.Join([]string{
+		"+---------+",
+		"| token   |",
+		"| expires |",
+		"| in 2    |",
+		"| days    |",
+		"+---------+",
+	}, "\n")
+	assert.Equal(t, exp, m.SBox("token expires in 2 days", WithMaxWidth(12), WithBorderStyle(ASCIIBorder)))
+
+	// callouts
+	out := m.SCallout(CalloutWarning, "token expires in %d days", 2)
+	assert.True(t, strings.HasPrefix(out, "\033[1;33m"))
+	assert.Contains(t, out, "╭─ ⚠ Warning ─")
+	assert.Contains(t, out, "token expires in 2 days")
+}
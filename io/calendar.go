@@ -0,0 +1,11 @@
+package io
+
+import "github.com/rollicks-c/term/io/calendar"
+
+func (m Module) Calendar(c *calendar.Calendar) {
+	m.Chart(c)
+}
+
+func (m Module) Heatmap(h *calendar.Heatmap) {
+	m.Chart(h)
+}
@@ -0,0 +1,209 @@
+package calendar
+
+import (
+	"fmt"
+	"github.com/rollicks-c/term/internal/text"
+	"github.com/rollicks-c/term/io/chart"
+	"github.com/rollicks-c/term/io/layout"
+	"strings"
+	"time"
+)
+
+type Style = func(...interface{}) string
+
+type Option func(config *Config)
+
+type Config struct {
+	From        time.Time
+	Months      int
+	WeekStart   time.Weekday
+	WeekNumbers bool
+	Today       time.Time
+	TodayStyle  Style
+}
+
+type Calendar struct {
+	config      *Config
+	highlights  []highlight
+	annotations map[time.Time]string
+}
+
+type highlight struct {
+	from, to time.Time
+	style    Style
+}
+
+func WithMonths(from time.Time, count int) Option {
+	return func(config *Config) {
+		config.From = from
+		config.Months = count
+	}
+}
+
+func WithWeekStart(day time.Weekday) Option {
+	return func(config *Config) {
+		config.WeekStart = day
+	}
+}
+
+func WithWeekNumbers(state bool) Option {
+	return func(config *Config) {
+		config.WeekNumbers = state
+	}
+}
+
+func WithToday(day time.Time) Option {
+	return func(config *Config) {
+		config.Today = day
+	}
+}
+
+func WithTodayStyle(style Style) Option {
+	return func(config *Config) {
+		config.TodayStyle = style
+	}
+}
+
+func New(options ...Option) *Calendar {
+	now := time.Now()
+	config := &Config{
+		From:        now,
+		Months:      1,
+		WeekStart:   time.Monday,
+		WeekNumbers: true,
+		Today:       now,
+	}
+	for _, opt := range options {
+		opt(config)
+	}
+	return &Calendar{
+		config:      config,
+		annotations: make(map[time.Time]string),
+	}
+}
+
+func (c *Calendar) Highlight(day time.Time, style Style) *Calendar {
+	return c.HighlightRange(day, day, style)
+}
+
+func (c *Calendar) HighlightRange(from, to time.Time, style Style) *Calendar {
+	c.highlights = append(c.highlights, highlight{
+		from:  dateOnly(from),
+		to:    dateOnly(to),
+		style: style,
+	})
+	return c
+}
+
+func (c *Calendar) Annotate(day time.Time, note string) *Calendar {
+	c.annotations[dateOnly(day)] = note
+	return c
+}
+
+func (c *Calendar) Render(width int, palette chart.Palette) string {
+
+	// today uses the first theme color unless given
+	todayStyle := c.config.TodayStyle
+	if todayStyle == nil && len(palette) > 0 {
+		todayStyle = palette[0]
+	}
+
+	// render months
+	first := time.Date(c.config.From.Year(), c.config.From.Month(), 1, 0, 0, 0, 0, time.UTC)
+	months := make([]string, max(c.config.Months, 1))
+	for i := range months {
+		months[i] = c.renderMonth(first.AddDate(0, i, 0), todayStyle)
+	}
+
+	// as many months side by side as fit
+	monthWidth := 0
+	for _, line := range strings.Split(months[0], "\n") {
+		monthWidth = max(monthWidth, text.Width(line))
+	}
+	perRow := max(min((width+2)/(monthWidth+2), len(months)), 1)
+	rows := make([]string, 0)
+	for i := 0; i < len(months); i += perRow {
+		rows = append(rows, layout.Horizontal(months[i:min(i+perRow, len(months))], layout.WithGap(2)))
+	}
+
+	return layout.Vertical(rows, layout.WithGap(1))
+}
+
+func (c *Calendar) renderMonth(first time.Time, todayStyle Style) string {
+
+	// cells fit day numbers and annotations
+	cellWidth, annotated := 2, false
+	for day, note := range c.annotations {
+		if day.Year() == first.Year() && day.Month() == first.Month() {
+			cellWidth = max(cellWidth, text.Width(note))
+			annotated = true
+		}
+	}
+
+	// header
+	cells := make([]string, 0, 8)
+	if c.config.WeekNumbers {
+		cells = append(cells, "Wk")
+	}
+	for i := 0; i < 7; i++ {
+		name := (c.config.WeekStart + time.Weekday(i)) % 7
+		cells = append(cells, text.PadLeft(name.String()[:2], cellWidth))
+	}
+	header := strings.Join(cells, " ")
+	monthWidth := text.Width(header)
+	title := fmt.Sprintf("%s %d", first.Month(), first.Year())
+	lines := []string{
+		strings.Repeat(" ", max(monthWidth-text.Width(title), 0)/2) + title,
+		header,
+	}
+
+	// weeks
+	start := first.AddDate(0, 0, -int((7+first.Weekday()-c.config.WeekStart)%7))
+	for week := start; week.Month() == first.Month() || week.Before(first); week = week.AddDate(0, 0, 7) {
+		days := make([]string, 0, 8)
+		notes := make([]string, 0, 8)
+		if c.config.WeekNumbers {
+			_, number := week.AddDate(0, 0, int((7+time.Monday-c.config.WeekStart)%7)).ISOWeek()
+			days = append(days, fmt.Sprintf("%2d", number))
+			notes = append(notes, "  ")
+		}
+		for i := 0; i < 7; i++ {
+			day := week.AddDate(0, 0, i)
+			if day.Month() != first.Month() {
+				days = append(days, strings.Repeat(" ", cellWidth))
+				notes = append(notes, strings.Repeat(" ", cellWidth))
+				continue
+			}
+			days = append(days, c.style(day, text.PadLeft(fmt.Sprintf("%d", day.Day()), cellWidth), todayStyle))
+			notes = append(notes, text.PadLeft(text.Truncate(c.annotations[day], cellWidth), cellWidth))
+		}
+		lines = append(lines, strings.Join(days, " "))
+		if annotated {
+			lines = append(lines, strings.Join(notes, " "))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (c *Calendar) style(day time.Time, cell string, todayStyle Style) string {
+
+	// today first
+	if todayStyle != nil && day.Equal(dateOnly(c.config.Today)) {
+		return todayStyle(cell)
+	}
+
+	// last matching highlight
+	for i := len(c.highlights) - 1; i >= 0; i-- {
+		h := c.highlights[i]
+		if !day.Before(h.from) && !day.After(h.to) {
+			return h.style(cell)
+		}
+	}
+
+	return cell
+}
+
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,86 @@
+package calendar
+
+import (
+	"github.com/rollicks-c/term/io/chart"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMonth(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, time.October, d, 0, 0, 0, 0, time.UTC) }
+	mark := func(v ...interface{}) string { return "[" + v[0].(string) + "]" }
+	now := func(v ...interface{}) string { return "<" + v[0].(string) + ">" }
+
+	cal := New(WithMonths(day(1), 1), WithToday(day(19)), WithTodayStyle(now)).
+		HighlightRange(day(5), day(6), mark)
+	exp := strings.Join([]string{
+		"     October 2026",
+		"Wk Mo Tu We Th Fr Sa Su",
+		"40           1  2  3  4",
+		"41 [ 5] [ 6]  7  8  9 10 11",
+		"42 12 13 14 15 16 17 18",
+		"43 <19> 20 21 22 23 24 25",
+		"44 26 27 28 29 30 31",
+	}, "\n")
+	assert.Equal(t, exp, cal.Render(80, nil))
+
+	// sunday start without week numbers, with annotations
+	cal = New(WithMonths(day(1), 1), WithToday(day(19)), WithWeekStart(time.Sunday), WithWeekNumbers(false)).
+		Annotate(day(2), "8h")
+	lines := strings.Split(cal.Render(80, nil), "\n")
+	assert.Equal(t, "Su Mo Tu We Th Fr Sa", lines[1])
+	assert.Equal(t, "             1  2  3", lines[2])
+	assert.Equal(t, "               8h", lines[3])
+
+	// months side by side
+	cal = New(WithMonths(day(1), 3), WithToday(day(19)))
+	lines = strings.Split(cal.Render(60, nil), "\n")
+	assert.Contains(t, lines[0], "October 2026")
+	assert.Contains(t, lines[0], "November 2026")
+	assert.NotContains(t, lines[0], "December 2026")
+}
+
+func TestHeatmap(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, time.March, d, 9, 0, 0, 0, time.UTC) }
+	values := map[time.Time]float64{
+		day(2):  8,
+		day(3):  2,
+		day(4):  2,
+		day(31): 5,
+	}
+
+	exp := strings.Join([]string{
+		"   Mar",
+		"Mo # . . . .",
+		"Tu - . . . *",
+		"We - . . .",
+		"Th . . . .",
+		"Fr . . . .",
+		"Sa . . . .",
+		"Su . . . .",
+		"   Less . - + * # More",
+	}, "\n")
+	heatmap := NewHeatmap(values, day(2), day(31), WithASCII())
+	assert.Equal(t, exp, heatmap.Render(80, nil))
+
+	// non-finite days are empty and leave the scale alone
+	values[day(5)] = math.NaN()
+	values[day(6)] = math.Inf(1)
+	lines := strings.Split(NewHeatmap(values, day(2), day(31), WithASCII()).Render(80, nil), "\n")
+	assert.Equal(t, "Mo # . . . .", lines[1])
+	assert.Equal(t, "Th . . . .", lines[4])
+	assert.Equal(t, "Fr . . . .", lines[5])
+	delete(values, day(5))
+	delete(values, day(6))
+
+	// levels keep their shades, tinted from the palette
+	tint := func(v ...interface{}) string { return "<" + v[0].(string) + ">" }
+	other := func(v ...interface{}) string { return "[" + v[0].(string) + "]" }
+	lines = strings.Split(heatmap.Render(80, chart.Palette{tint, other}), "\n")
+	assert.Equal(t, "Mo <#> . . . .", lines[1])
+	assert.Equal(t, "Tu <-> . . . <*>", lines[2])
+	assert.Equal(t, "   Less . <-> <+> <*> <#> More", lines[8])
+}
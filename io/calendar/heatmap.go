@@ -0,0 +1,173 @@
+package calendar
+
+import (
+	"github.com/rollicks-c/term/io/chart"
+	"math"
+	"strings"
+	"time"
+)
+
+const defaultBuckets = 4
+
+var (
+	heatGlyphs      = []string{"·", "░", "▒", "▓", "█"}
+	heatGlyphsASCII = []string{".", "-", "+", "*", "#"}
+)
+
+type Heatmap struct {
+	values    map[time.Time]float64
+	from, to  time.Time
+	weekStart time.Weekday
+	buckets   int
+	styles    []Style
+	ascii     bool
+}
+
+type HeatmapOption func(heatmap *Heatmap)
+
+func WithBuckets(count int) HeatmapOption {
+	return func(heatmap *Heatmap) {
+		heatmap.buckets = count
+	}
+}
+
+func WithBucketStyles(styles ...Style) HeatmapOption {
+	return func(heatmap *Heatmap) {
+		heatmap.styles = styles
+	}
+}
+
+func WithFirstWeekday(day time.Weekday) HeatmapOption {
+	return func(heatmap *Heatmap) {
+		heatmap.weekStart = day
+	}
+}
+
+func WithASCII() HeatmapOption {
+	return func(heatmap *Heatmap) {
+		heatmap.ascii = true
+	}
+}
+
+func NewHeatmap(values map[time.Time]float64, from, to time.Time, options ...HeatmapOption) *Heatmap {
+
+	// values are summed per day
+	days := make(map[time.Time]float64, len(values))
+	for t, v := range values {
+		days[dateOnly(t)] += v
+	}
+
+	h := &Heatmap{
+		values:    days,
+		from:      dateOnly(from),
+		to:        dateOnly(to),
+		weekStart: time.Monday,
+		buckets:   defaultBuckets,
+	}
+	for _, opt := range options {
+		opt(h)
+	}
+	h.buckets = max(h.buckets, 1)
+
+	return h
+}
+
+func NewYearHeatmap(values map[time.Time]float64, year int, options ...HeatmapOption) *Heatmap {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return NewHeatmap(values, from, from.AddDate(1, 0, -1), options...)
+}
+
+func (h *Heatmap) Render(width int, palette chart.Palette) string {
+
+	// show the most recent weeks that fit
+	from, to := h.from, h.to
+	start := from.AddDate(0, 0, -int((7+from.Weekday()-h.weekStart)%7))
+	weeks := int(to.Sub(start).Hours()/24)/7 + 1
+	if fit := max((width-3)/2, 1); weeks > fit {
+		start = start.AddDate(0, 0, 7*(weeks-fit))
+		from = start
+		weeks = fit
+	}
+
+	// scale to largest finite value in range
+	scale := 0.0
+	for day, v := range h.values {
+		if !day.Before(from) && !day.After(to) && !math.IsNaN(v) && !math.IsInf(v, 0) {
+			scale = math.Max(scale, v)
+		}
+	}
+
+	// month labels at the first week of each month and at the start
+	labels := strings.Builder{}
+	labels.WriteString("   ")
+	cursor := 0
+	for week := 0; week < weeks; week++ {
+		first := start.AddDate(0, 0, 7*week)
+		for d := 0; d < 7; d++ {
+			day := first.AddDate(0, 0, d)
+			if (day.Day() != 1 && !day.Equal(from)) || day.Before(from) || day.After(to) || 2*week < cursor {
+				continue
+			}
+			labels.WriteString(strings.Repeat(" ", 2*week-cursor) + day.Month().String()[:3])
+			cursor = 2*week + 3
+		}
+	}
+	lines := []string{strings.TrimRight(labels.String(), " ")}
+
+	// weekday rows
+	for d := 0; d < 7; d++ {
+		row := strings.Builder{}
+		row.WriteString(((h.weekStart + time.Weekday(d)) % 7).String()[:2] + " ")
+		for week := 0; week < weeks; week++ {
+			day := start.AddDate(0, 0, 7*week+d)
+			if day.Before(from) || day.After(to) {
+				row.WriteString("  ")
+				continue
+			}
+			row.WriteString(h.cell(h.bucket(h.values[day], scale), palette) + " ")
+		}
+		lines = append(lines, strings.TrimRight(row.String(), " "))
+	}
+
+	// legend
+	legend := make([]string, h.buckets+1)
+	for i := range legend {
+		legend[i] = h.cell(i, palette)
+	}
+	lines = append(lines, "   Less "+strings.Join(legend, " ")+" More")
+
+	return strings.Join(lines, "\n")
+}
+
+func (h *Heatmap) bucket(value, scale float64) int {
+	if value <= 0 || scale <= 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0
+	}
+	return min(max(int(math.Ceil(value/scale*float64(h.buckets))), 1), h.buckets)
+}
+
+func (h *Heatmap) cell(bucket int, palette chart.Palette) string {
+	glyphs := heatGlyphs
+	if h.ascii {
+		glyphs = heatGlyphsASCII
+	}
+	glyph := glyphs[0]
+
+	// shade by bucket position, tinted with the palette's first color
+	style := Style(nil)
+	if bucket > 0 {
+		glyph = glyphs[1+(bucket-1)*(len(glyphs)-2)/max(h.buckets-1, 1)]
+		if len(palette) > 0 {
+			style = palette[0]
+		}
+	}
+
+	// explicit styles per bucket win
+	if bucket < len(h.styles) && h.styles[bucket] != nil {
+		style = h.styles[bucket]
+	}
+	if style == nil {
+		return glyph
+	}
+	return style(glyph)
+}
@@ -0,0 +1,34 @@
+package io
+
+import (
+	"fmt"
+	"github.com/rollicks-c/term/internal/tty"
+	"github.com/rollicks-c/term/io/chart"
+)
+
+const defaultChartWidth = 80
+
+func (m Module) Chart(c chart.Chart) {
+	if _, err := fmt.Fprintln(m.out, m.SChart(c)); err != nil {
+		fmt.Print(err)
+	}
+}
+
+func (m Module) SChart(c chart.Chart) string {
+	return c.Render(m.chartWidth(), m.Palette())
+}
+
+func (m Module) Palette() chart.Palette {
+	return chart.Palette{Info, Success, Warn, Fatal, Magenta, Teal}
+}
+
+func (m Module) chartWidth() int {
+	if width, _, ok := tty.Size(m.out); ok {
+		return width
+	}
+	return defaultChartWidth
+}
+
+func (m Module) Timeline(intervals []chart.Interval, options ...chart.Option) {
+	m.Chart(chart.NewTimeline(intervals, options...))
+}
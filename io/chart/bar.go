@@ -0,0 +1,258 @@
+package chart
+
+import (
+	"github.com/rollicks-c/term/internal/text"
+	"math"
+	"slices"
+	"strings"
+)
+
+var (
+	hBlocks = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+	vBlocks = []string{" ", "▁", "▂", "▃", "▄", "▅", "▆", "▇"}
+)
+
+type BarChart struct {
+	labels []string
+	series []Series
+	config *Config
+}
+
+func Bars(labels []string, series []Series, options ...Option) *BarChart {
+	return &BarChart{
+		labels: labels,
+		series: series,
+		config: newConfig(options...),
+	}
+}
+
+func BarsFromMap(values map[string]float64, options ...Option) *BarChart {
+	labels := make([]string, 0, len(values))
+	for label := range values {
+		labels = append(labels, label)
+	}
+	slices.Sort(labels)
+	series := Series{Values: make([]float64, len(labels))}
+	for i, label := range labels {
+		series.Values[i] = values[label]
+	}
+	return Bars(labels, []Series{series}, options...)
+}
+
+func Histogram(values []float64, options ...Option) *BarChart {
+
+	// non-finite values cannot be binned
+	values = slices.DeleteFunc(slices.Clone(values), func(v float64) bool { return !finite(v) })
+
+	// bin count by Sturges' rule unless given
+	config := newConfig(options...)
+	bins := config.Bins
+	if bins <= 0 {
+		bins = int(math.Ceil(math.Log2(float64(max(len(values), 1))))) + 1
+	}
+
+	// value range
+	lo, hi := 0.0, 1.0
+	if len(values) > 0 {
+		lo, hi = slices.Min(values), slices.Max(values)
+	}
+	if hi == lo {
+		hi = lo + 1
+	}
+	size := (hi - lo) / float64(bins)
+
+	// count values per bin, the last bin includes its upper bound
+	counts := make([]float64, bins)
+	for _, v := range values {
+		bin := min(int((v-lo)/size), bins-1)
+		counts[bin]++
+	}
+	labels := make([]string, bins)
+	for i := range labels {
+		labels[i] = formatValue(lo+float64(i)*size) + "-" + formatValue(lo+float64(i+1)*size)
+	}
+
+	return &BarChart{
+		labels: labels,
+		series: []Series{{Values: counts}},
+		config: config,
+	}
+}
+
+func (c *BarChart) Render(width int, palette Palette) string {
+	if c.config.Vertical {
+		return decorate(c.config, c.series, palette, c.renderVertical(width, palette))
+	}
+	return decorate(c.config, c.series, palette, c.renderHorizontal(width, palette))
+}
+
+func (c *BarChart) renderHorizontal(width int, palette Palette) []string {
+	g := c.config.glyphs()
+
+	// measure labels and values
+	labelWidth, valueWidth := 0, 0
+	for i, label := range c.labels {
+		labelWidth = max(labelWidth, text.Width(label))
+		total := 0.0
+		for _, v := range c.values(i) {
+			valueWidth = max(valueWidth, text.Width(formatValue(v)))
+			total += v
+		}
+		if c.config.Stacked {
+			valueWidth = max(valueWidth, text.Width(formatValue(total)))
+		}
+	}
+	barWidth := max(width-labelWidth-valueWidth-3, 1)
+	scale := c.scale()
+
+	// one line per label, or per label and series when grouped
+	lines := make([]string, 0)
+	for i, label := range c.labels {
+		prefix := text.Pad(label, labelWidth) + " " + g.vertical
+		if c.config.Stacked {
+			bar, total := c.stackedBar(i, barWidth, scale, palette, g.full)
+			lines = append(lines, prefix+bar+" "+formatValue(total))
+			continue
+		}
+		for j := range c.series {
+			value := c.value(j, i)
+			bar := c.bar(value/scale*float64(barWidth), g.full)
+			lines = append(lines, prefix+seriesColor(c.series, j, palette)(bar)+" "+formatValue(value))
+			prefix = strings.Repeat(" ", labelWidth+1) + g.vertical
+		}
+	}
+
+	// axis with scale
+	lines = append(lines, strings.Repeat(" ", labelWidth+1)+g.corner+strings.Repeat(g.horizontal, barWidth))
+	maxLabel := formatValue(scale)
+	lines = append(lines, strings.Repeat(" ", labelWidth+2)+"0"+text.PadLeft(maxLabel, barWidth-1))
+
+	return lines
+}
+
+func (c *BarChart) renderVertical(width int, palette Palette) []string {
+	g := c.config.glyphs()
+	height := c.config.Height
+	scale := c.scale()
+
+	// y axis labels at top and bottom
+	labels := map[int]string{0: formatValue(scale), height - 1: "0"}
+	axis, axisWidth := yAxis(labels, height)
+
+	// bar columns per label group
+	columns := len(c.series)
+	if c.config.Stacked {
+		columns = 1
+	}
+	groupWidth := max((width-axisWidth-1)/max(len(c.labels), 1), columns+1)
+	barWidth := max(min((groupWidth-1)/columns, 3), 1)
+	groupWidth = barWidth*columns + 1
+
+	// draw rows top down
+	lines := make([]string, height)
+	for row := range lines {
+		level := height - 1 - row
+		out := strings.Builder{}
+		for i := range c.labels {
+			out.WriteString(" ")
+			if c.config.Stacked {
+				out.WriteString(c.stackedCell(i, level, scale, palette, g.full, barWidth))
+				continue
+			}
+			for j := range c.series {
+				eighths := int(math.Round(c.value(j, i) / scale * float64(height*8)))
+				cell := g.full
+				switch {
+				case eighths >= (level+1)*8:
+				case eighths > level*8 && !c.config.ASCII:
+					cell = vBlocks[eighths-level*8]
+				default:
+					cell = " "
+				}
+				out.WriteString(seriesColor(c.series, j, palette)(strings.Repeat(cell, barWidth)))
+			}
+		}
+		tick := g.vertical
+		if _, ok := labels[row]; ok {
+			tick = g.tick
+		}
+		lines[row] = axis[row] + tick + out.String()
+	}
+
+	// x axis with labels
+	lines = append(lines, strings.Repeat(" ", axisWidth)+g.corner+strings.Repeat(g.horizontal, groupWidth*len(c.labels)))
+	xAxis := strings.Builder{}
+	for _, label := range c.labels {
+		xAxis.WriteString(" " + text.Pad(text.Truncate(label, groupWidth-1), groupWidth-1))
+	}
+	lines = append(lines, strings.TrimRight(strings.Repeat(" ", axisWidth)+xAxis.String(), " "))
+
+	return lines
+}
+
+func (c *BarChart) stackedBar(label, barWidth int, scale float64, palette Palette, full string) (string, float64) {
+	out := strings.Builder{}
+	total, drawn := 0.0, 0
+	for j := range c.series {
+		total += c.value(j, label)
+		end := int(math.Round(total / scale * float64(barWidth)))
+		if end > drawn {
+			out.WriteString(seriesColor(c.series, j, palette)(strings.Repeat(full, end-drawn)))
+			drawn = end
+		}
+	}
+	return out.String(), total
+}
+
+func (c *BarChart) stackedCell(label, level int, scale float64, palette Palette, full string, barWidth int) string {
+	total := 0.0
+	for j := range c.series {
+		total += c.value(j, label)
+		if int(math.Round(total/scale*float64(c.config.Height))) > level {
+			return seriesColor(c.series, j, palette)(strings.Repeat(full, barWidth))
+		}
+	}
+	return strings.Repeat(" ", barWidth)
+}
+
+func (c *BarChart) bar(cells float64, full string) string {
+	if c.config.ASCII {
+		return strings.Repeat(full, int(math.Round(cells)))
+	}
+	eighths := int(math.Round(cells * 8))
+	return strings.Repeat(full, eighths/8) + hBlocks[eighths%8]
+}
+
+func (c *BarChart) scale() float64 {
+	scale := 0.0
+	for i := range c.labels {
+		total := 0.0
+		for _, v := range c.values(i) {
+			scale = math.Max(scale, v)
+			total += v
+		}
+		if c.config.Stacked {
+			scale = math.Max(scale, total)
+		}
+	}
+	if scale == 0 {
+		return 1
+	}
+	return scale
+}
+
+func (c *BarChart) values(label int) []float64 {
+	values := make([]float64, len(c.series))
+	for j := range c.series {
+		values[j] = c.value(j, label)
+	}
+	return values
+}
+
+func (c *BarChart) value(series, label int) float64 {
+	values := c.series[series].Values
+	if label >= len(values) || !finite(values[label]) {
+		return 0
+	}
+	return math.Max(values[label], 0)
+}
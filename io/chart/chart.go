@@ -0,0 +1,203 @@
+package chart
+
+import (
+	"fmt"
+	"github.com/rollicks-c/term/internal/text"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const defaultHeight = 10
+
+type Color = func(...interface{}) string
+
+type Palette []Color
+
+type Chart interface {
+	Render(width int, palette Palette) string
+}
+
+type Marker int
+
+const (
+	Braille Marker = iota
+	Blocks
+)
+
+type Option func(config *Config)
+
+type Config struct {
+	Title      string
+	Height     int
+	Vertical   bool
+	Stacked    bool
+	Bins       int
+	Marker     Marker
+	XLabels    []string
+	HideLegend bool
+	ASCII      bool
+}
+
+type Series struct {
+	Name   string
+	Values []float64
+	Color  Color
+}
+
+func WithTitle(title string) Option {
+	return func(config *Config) {
+		config.Title = title
+	}
+}
+
+func WithHeight(rows int) Option {
+	return func(config *Config) {
+		config.Height = rows
+	}
+}
+
+func WithVertical() Option {
+	return func(config *Config) {
+		config.Vertical = true
+	}
+}
+
+func WithStacked() Option {
+	return func(config *Config) {
+		config.Stacked = true
+	}
+}
+
+func WithBins(count int) Option {
+	return func(config *Config) {
+		config.Bins = count
+	}
+}
+
+func WithMarker(marker Marker) Option {
+	return func(config *Config) {
+		config.Marker = marker
+	}
+}
+
+func WithXLabels(labels ...string) Option {
+	return func(config *Config) {
+		config.XLabels = labels
+	}
+}
+
+func WithHideLegend() Option {
+	return func(config *Config) {
+		config.HideLegend = true
+	}
+}
+
+func WithASCII() Option {
+	return func(config *Config) {
+		config.ASCII = true
+	}
+}
+
+func newConfig(options ...Option) *Config {
+	config := &Config{
+		Height: defaultHeight,
+		Marker: Braille,
+	}
+	for _, opt := range options {
+		opt(config)
+	}
+	config.Height = max(config.Height, 2)
+	return config
+}
+
+func (c *Config) glyphs() glyphSet {
+	if c.ASCII {
+		return asciiGlyphs
+	}
+	return unicodeGlyphs
+}
+
+type glyphSet struct {
+	full       string
+	horizontal string
+	vertical   string
+	tick       string
+	corner     string
+	mark       string
+	legend     string
+}
+
+var (
+	unicodeGlyphs = glyphSet{full: "█", horizontal: "─", vertical: "│", tick: "┤", corner: "└", mark: "┬", legend: "■"}
+	asciiGlyphs   = glyphSet{full: "#", horizontal: "-", vertical: "|", tick: "+", corner: "+", mark: "+", legend: "#"}
+)
+
+func seriesColor(series []Series, index int, palette Palette) Color {
+	if series[index].Color != nil {
+		return series[index].Color
+	}
+	return paletteColor(palette, index)
+}
+
+func paletteColor(palette Palette, index int) Color {
+	if len(palette) > 0 {
+		return palette[index%len(palette)]
+	}
+	return plain
+}
+
+func plain(v ...interface{}) string {
+	return fmt.Sprint(v...)
+}
+
+func decorate(config *Config, series []Series, palette Palette, body []string) string {
+
+	// title
+	lines := make([]string, 0, len(body)+2)
+	if config.Title != "" {
+		lines = append(lines, config.Title)
+	}
+	lines = append(lines, body...)
+
+	// legend for named series
+	if !config.HideLegend {
+		entries := make([]string, 0, len(series))
+		for i, s := range series {
+			if s.Name == "" {
+				continue
+			}
+			color := seriesColor(series, i, palette)
+			entries = append(entries, color(config.glyphs().legend)+" "+s.Name)
+		}
+		if len(entries) > 0 {
+			lines = append(lines, strings.Join(entries, "  "))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func finite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
+func formatValue(value float64) string {
+	if value == math.Trunc(value) && math.Abs(value) < 1e15 {
+		return strconv.FormatFloat(value, 'f', 0, 64)
+	}
+	out := strconv.FormatFloat(value, 'f', 2, 64)
+	return strings.TrimRight(strings.TrimRight(out, "0"), ".")
+}
+
+func yAxis(labels map[int]string, rows int) ([]string, int) {
+	width := 0
+	for _, label := range labels {
+		width = max(width, text.Width(label))
+	}
+	out := make([]string, rows)
+	for row := range out {
+		out[row] = text.PadLeft(labels[row], width)
+	}
+	return out, width
+}
@@ -0,0 +1,112 @@
+package chart
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBars(t *testing.T) {
+	series := []Series{{Name: "a", Values: []float64{2, 4}}, {Name: "b", Values: []float64{2, 0}}}
+
+	// grouped
+	exp := strings.Join([]string{
+		"mon │█████ 2",
+		"    │█████ 2",
+		"tue │██████████ 4",
+		"    │ 0",
+		"    └──────────",
+		"     0        4",
+		"■ a  ■ b",
+	}, "\n")
+	assert.Equal(t, exp, Bars([]string{"mon", "tue"}, series).Render(17, nil))
+
+	// stacked and vertical
+	exp = strings.Join([]string{
+		"4+ ### ###",
+		" | ### ###",
+		" | ### ###",
+		"0+ ### ###",
+		" +--------",
+		"  mon tue",
+		"# a  # b",
+	}, "\n")
+	assert.Equal(t, exp, Bars([]string{"mon", "tue"}, series, WithVertical(), WithStacked(), WithHeight(4), WithASCII()).Render(20, nil))
+
+	// colors from palette
+	red := func(v ...interface{}) string { return "<" + v[0].(string) + ">" }
+	out := BarsFromMap(map[string]float64{"x": 1}, WithASCII()).Render(10, Palette{red})
+	assert.Contains(t, out, "x |<#####> 1")
+
+	// non-finite values are not drawn
+	exp = strings.Join([]string{
+		"a |##### 2",
+		"b | 0",
+		"c | 0",
+		"  +-----",
+		"   0   2",
+	}, "\n")
+	series = []Series{{Values: []float64{2, math.NaN(), math.Inf(1)}}}
+	assert.Equal(t, exp, Bars([]string{"a", "b", "c"}, series, WithASCII()).Render(10, nil))
+}
+
+func TestHistogram(t *testing.T) {
+	exp := strings.Join([]string{
+		"1-3 │████▋ 2",
+		"3-5 │███████ 3",
+		"    └───────",
+		"     0     3",
+	}, "\n")
+	assert.Equal(t, exp, Histogram([]float64{1, 2, 3, 4, 5}, WithBins(2)).Render(14, nil))
+
+	// non-finite values are left out of range and bins
+	values := []float64{1, 2, 3, 4, 5, math.NaN(), math.Inf(-1), math.Inf(1)}
+	assert.Equal(t, exp, Histogram(values, WithBins(2)).Render(14, nil))
+}
+
+func TestLines(t *testing.T) {
+	exp := strings.Join([]string{
+		"3+  *",
+		"2+ * *",
+		"1+*   *",
+		" +-----",
+		"  a   b",
+	}, "\n")
+	chart := Lines([]Series{{Values: []float64{1, 2, 3, 2, 1}}}, WithHeight(3), WithASCII(), WithXLabels("a", "b"))
+	assert.Equal(t, exp, chart.Render(7, nil))
+
+	// gaps for non-finite values
+	exp = strings.Join([]string{
+		"3+  *",
+		"2+ *",
+		"1+*   *",
+		" +-----",
+	}, "\n")
+	chart = Lines([]Series{{Values: []float64{1, 2, 3, math.NaN(), 1}}}, WithHeight(3), WithASCII())
+	assert.Equal(t, exp, chart.Render(7, nil))
+
+	// at least two rows
+	chart = Lines([]Series{{Values: []float64{1, 2}}}, WithHeight(1), WithASCII())
+	assert.Len(t, strings.Split(chart.Render(7, nil), "\n"), 3)
+}
+
+func TestTimeline(t *testing.T) {
+	at := func(h int) time.Time { return time.Date(2026, time.October, 19, h, 0, 0, 0, time.UTC) }
+	intervals := []Interval{
+		{Start: at(8), End: at(10), Label: "mail"},
+		{Start: at(9), End: at(12), Label: "project"},
+		{Start: at(12), End: at(14), Label: "review"},
+	}
+
+	exp := strings.Join([]string{
+		"######     ######",
+		"mail       review",
+		"   ########",
+		"   project",
+		"+--------+-------+",
+		"08:00    11:00",
+	}, "\n")
+	assert.Equal(t, exp, NewTimeline(intervals, WithASCII()).Render(18, nil))
+}
@@ -0,0 +1,233 @@
+package chart
+
+import (
+	"github.com/rollicks-c/term/internal/text"
+	"math"
+	"strings"
+)
+
+var brailleDots = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+var halfBlocks = []string{" ", "▀", "▄", "█"}
+
+type LineChart struct {
+	series []Series
+	config *Config
+}
+
+type canvas struct {
+	cols, rows   int
+	cellW, cellH int
+	dots         [][]rune
+	colors       [][]int
+}
+
+func Lines(series []Series, options ...Option) *LineChart {
+	return &LineChart{
+		series: series,
+		config: newConfig(options...),
+	}
+}
+
+func (c *LineChart) Render(width int, palette Palette) string {
+	g := c.config.glyphs()
+	height := c.config.Height
+
+	// value range
+	lo, hi := math.Inf(1), math.Inf(-1)
+	points := 0
+	for _, s := range c.series {
+		for _, v := range s.Values {
+			if finite(v) {
+				lo, hi = math.Min(lo, v), math.Max(hi, v)
+			}
+		}
+		points = max(points, len(s.Values))
+	}
+	if lo > hi {
+		lo, hi = 0, 1
+	}
+	if hi == lo {
+		lo, hi = lo-1, hi+1
+	}
+
+	// y axis labels at top, middle and bottom
+	labels := map[int]string{
+		0:          formatValue(hi),
+		height / 2: formatValue(hi - (hi-lo)*float64(height/2)/float64(max(height-1, 1))),
+		height - 1: formatValue(lo),
+	}
+	axis, axisWidth := yAxis(labels, height)
+
+	// plot series
+	cellW, cellH := c.marker()
+	cv := newCanvas(max(width-axisWidth-1, 1), height, cellW, cellH)
+	dotsW, dotsH := cv.cols*cv.cellW, cv.rows*cv.cellH
+	for j, s := range c.series {
+		px, py := -1, -1
+		for i, v := range s.Values {
+
+			// gaps break the line
+			if !finite(v) {
+				px, py = -1, -1
+				continue
+			}
+			x := 0
+			if points > 1 {
+				x = int(math.Round(float64(i) * float64(dotsW-1) / float64(points-1)))
+			}
+			y := int(math.Round((v - lo) / (hi - lo) * float64(dotsH-1)))
+			if px < 0 {
+				cv.set(x, y, j)
+			} else {
+				cv.line(px, py, x, y, j)
+			}
+			px, py = x, y
+		}
+	}
+
+	// draw rows with axis
+	lines := make([]string, height)
+	for row := range lines {
+		tick := g.vertical
+		if _, ok := labels[row]; ok {
+			tick = g.tick
+		}
+		lines[row] = axis[row] + tick + cv.renderRow(row, c.series, palette, c.config.ASCII)
+	}
+	lines = append(lines, strings.Repeat(" ", axisWidth)+g.corner+strings.Repeat(g.horizontal, cv.cols))
+	if len(c.config.XLabels) > 0 {
+		lines = append(lines, strings.Repeat(" ", axisWidth+1)+xLabels(c.config.XLabels, cv.cols))
+	}
+
+	return decorate(c.config, c.series, palette, lines)
+}
+
+func (c *LineChart) marker() (int, int) {
+	switch {
+	case c.config.ASCII:
+		return 1, 1
+	case c.config.Marker == Blocks:
+		return 1, 2
+	}
+	return 2, 4
+}
+
+func newCanvas(cols, rows, cellW, cellH int) *canvas {
+	cv := &canvas{
+		cols:   cols,
+		rows:   rows,
+		cellW:  cellW,
+		cellH:  cellH,
+		dots:   make([][]rune, rows),
+		colors: make([][]int, rows),
+	}
+	for row := range cv.dots {
+		cv.dots[row] = make([]rune, cols)
+		cv.colors[row] = make([]int, cols)
+	}
+	return cv
+}
+
+func (cv *canvas) set(x, y, series int) {
+
+	// dot coordinates start bottom left
+	col, row := x/cv.cellW, cv.rows-1-y/cv.cellH
+	if col < 0 || col >= cv.cols || row < 0 || row >= cv.rows {
+		return
+	}
+	dx, dy := x%cv.cellW, cv.cellH-1-y%cv.cellH
+
+	// encode dot within its cell
+	switch {
+	case cv.cellH == 4:
+		cv.dots[row][col] |= brailleDots[dx][dy]
+	case cv.cellH == 2:
+		cv.dots[row][col] |= 1 << dy
+	default:
+		cv.dots[row][col] = 1
+	}
+	cv.colors[row][col] = series
+}
+
+func (cv *canvas) line(x0, y0, x1, y1, series int) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := sign(x1-x0), sign(y1-y0)
+	err := dx + dy
+	for {
+		cv.set(x0, y0, series)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+func (cv *canvas) renderRow(row int, series []Series, palette Palette, ascii bool) string {
+	out := strings.Builder{}
+	for col, dots := range cv.dots[row] {
+		if dots == 0 {
+			out.WriteString(" ")
+			continue
+		}
+		glyph := "*"
+		switch {
+		case ascii:
+		case cv.cellH == 4:
+			glyph = string(0x2800 + dots)
+		case cv.cellH == 2:
+			glyph = halfBlocks[dots]
+		}
+		out.WriteString(seriesColor(series, cv.colors[row][col], palette)(glyph))
+	}
+	return strings.TrimRight(out.String(), " ")
+}
+
+func xLabels(labels []string, width int) string {
+
+	// spread labels evenly, skipping those that would overlap
+	out := strings.Builder{}
+	cursor := 0
+	for i, label := range labels {
+		pos := 0
+		if len(labels) > 1 {
+			pos = i * (width - 1) / (len(labels) - 1)
+		}
+		pos = min(pos, width-text.Width(label))
+		if pos < cursor {
+			continue
+		}
+		out.WriteString(strings.Repeat(" ", pos-cursor) + label)
+		cursor = pos + text.Width(label) + 1
+		out.WriteString(" ")
+	}
+	return strings.TrimRight(out.String(), " ")
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func sign(v int) int {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,228 @@
+package chart
+
+import (
+	"fmt"
+	"github.com/rollicks-c/term/internal/text"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+type Interval struct {
+	Start time.Time
+	End   time.Time
+	Label string
+	Color Color
+}
+
+type Timeline struct {
+	intervals []Interval
+	config    *Config
+}
+
+type tickUnit struct {
+	steps  []int
+	start  func(t time.Time) time.Time
+	next   func(t time.Time, step int) time.Time
+	format func(t time.Time) string
+}
+
+var (
+	hourTicks = tickUnit{
+		steps: []int{1, 2, 3, 4, 6, 12},
+		start: func(t time.Time) time.Time {
+			return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+		},
+		next: func(t time.Time, step int) time.Time {
+			return t.Add(time.Duration(step) * time.Hour)
+		},
+		format: func(t time.Time) string {
+			return t.Format("15:04")
+		},
+	}
+	dayTicks = tickUnit{
+		steps: []int{1, 2, 3, 5, 10},
+		start: func(t time.Time) time.Time {
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		},
+		next: func(t time.Time, step int) time.Time {
+			return t.AddDate(0, 0, step)
+		},
+		format: func(t time.Time) string {
+			return t.Format("Jan 02")
+		},
+	}
+	weekTicks = tickUnit{
+		steps: []int{1, 2, 4, 8, 13, 26},
+		start: func(t time.Time) time.Time {
+			monday := t.AddDate(0, 0, -int((t.Weekday()+6)%7))
+			return time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, t.Location())
+		},
+		next: func(t time.Time, step int) time.Time {
+			return t.AddDate(0, 0, 7*step)
+		},
+		format: func(t time.Time) string {
+			_, week := t.ISOWeek()
+			return fmt.Sprintf("W%02d", week)
+		},
+	}
+)
+
+func NewTimeline(intervals []Interval, options ...Option) *Timeline {
+	return &Timeline{
+		intervals: intervals,
+		config:    newConfig(options...),
+	}
+}
+
+func (c *Timeline) Render(width int, palette Palette) string {
+	g := c.config.glyphs()
+	if len(c.intervals) == 0 {
+		return decorate(c.config, nil, palette, nil)
+	}
+
+	// time span of all intervals
+	from, to := c.intervals[0].Start, c.intervals[0].End
+	for _, iv := range c.intervals {
+		from, to = minTime(from, iv.Start), maxTime(to, iv.End)
+	}
+	if !to.After(from) {
+		to = from.Add(time.Hour)
+	}
+	width = max(width, 1)
+	column := func(t time.Time) int {
+		ratio := float64(t.Sub(from)) / float64(to.Sub(from))
+		return min(int(math.Round(ratio*float64(width-1))), width-1)
+	}
+
+	// one bar and one label line per lane
+	lines := make([]string, 0)
+	for _, lane := range c.lanes() {
+		bars := make([]string, width)
+		labels := make([]string, 0, len(lane))
+		for i := range bars {
+			bars[i] = " "
+		}
+		cursor := 0
+		for k, index := range lane {
+			iv := c.intervals[index]
+			x0 := column(iv.Start)
+			x1 := max(column(iv.End)-1, x0)
+			color := iv.Color
+			if color == nil {
+				color = paletteColor(palette, index)
+			}
+			for x := x0; x <= x1; x++ {
+				bars[x] = color(g.full)
+			}
+
+			// labels run until the next interval in the lane
+			limit := width
+			if k+1 < len(lane) {
+				limit = column(c.intervals[lane[k+1]].Start)
+			}
+			label := text.Truncate(iv.Label, max(limit-x0-1, 1))
+			if x0 >= cursor {
+				labels = append(labels, strings.Repeat(" ", x0-cursor)+label)
+				cursor = x0 + text.Width(label)
+			}
+		}
+		lines = append(lines, strings.TrimRight(strings.Join(bars, ""), " "))
+		lines = append(lines, strings.TrimRight(strings.Join(labels, ""), " "))
+	}
+
+	// axis with ticks
+	axis := []rune(strings.Repeat(g.horizontal, width))
+	tickLabels := strings.Builder{}
+	cursor := 0
+	for _, tick := range c.ticks(from, to, width) {
+		x := column(tick)
+		axis[x] = []rune(g.mark)[0]
+		label := c.tickUnit(to.Sub(from)).format(tick)
+		if x < cursor || x+text.Width(label) > width {
+			continue
+		}
+		tickLabels.WriteString(strings.Repeat(" ", x-cursor) + label)
+		cursor = x + text.Width(label) + 1
+		tickLabels.WriteString(" ")
+	}
+	lines = append(lines, string(axis), strings.TrimRight(tickLabels.String(), " "))
+
+	return decorate(c.config, nil, palette, lines)
+}
+
+func (c *Timeline) lanes() [][]int {
+
+	// order by start
+	order := make([]int, len(c.intervals))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return c.intervals[a].Start.Compare(c.intervals[b].Start)
+	})
+
+	// first lane that is free again
+	lanes := make([][]int, 0)
+	ends := make([]time.Time, 0)
+	for _, index := range order {
+		iv := c.intervals[index]
+		lane := slices.IndexFunc(ends, func(end time.Time) bool {
+			return !end.After(iv.Start)
+		})
+		if lane < 0 {
+			lanes = append(lanes, nil)
+			ends = append(ends, time.Time{})
+			lane = len(lanes) - 1
+		}
+		lanes[lane] = append(lanes[lane], index)
+		ends[lane] = maxTime(iv.Start, iv.End)
+	}
+
+	return lanes
+}
+
+func (c *Timeline) tickUnit(span time.Duration) tickUnit {
+	switch {
+	case span <= 48*time.Hour:
+		return hourTicks
+	case span <= 60*24*time.Hour:
+		return dayTicks
+	}
+	return weekTicks
+}
+
+func (c *Timeline) ticks(from, to time.Time, width int) []time.Time {
+
+	// smallest step whose labels fit the width
+	unit := c.tickUnit(to.Sub(from))
+	var ticks []time.Time
+	for _, step := range unit.steps {
+		ticks = ticks[:0]
+		for t := unit.start(from); !t.After(to); t = unit.next(t, step) {
+			if !t.Before(from) {
+				ticks = append(ticks, t)
+			}
+		}
+		if len(ticks)*(text.Width(unit.format(from))+1) <= width {
+			break
+		}
+	}
+
+	return ticks
+}
+
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
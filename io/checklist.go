@@ -0,0 +1,228 @@
+package io
+
+import (
+	"fmt"
+	"github.com/rollicks-c/term/internal/text"
+	"github.com/rollicks-c/term/internal/tty"
+	"github.com/rollicks-c/term/io/table"
+	"io"
+	"slices"
+	"strings"
+)
+
+var ErrCanceled = table.ErrCanceled
+
+type checklist struct {
+	label    string
+	items    []string
+	checked  []bool
+	disabled []bool
+	min      int
+	max      int
+	filter   string
+	cursor   int
+	offset   int
+	height   int
+	status   string
+}
+
+func runChecklist(in io.Reader, out io.Writer, s MultiSelection) ([]int, error) {
+
+	// enter full screen
+	term, err := tty.Open(in, out)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = term.Close()
+	}()
+
+	// interact
+	c := newChecklist(s)
+	for {
+		width, height := term.Size()
+		c.height = max(height-3, 1)
+		if err := term.Draw(c.view(width)); err != nil {
+			return nil, err
+		}
+		key, err := term.ReadKey()
+		if err != nil {
+			return nil, err
+		}
+		done, canceled := c.handle(key)
+		if canceled {
+			return nil, ErrCanceled
+		}
+		if done {
+			return c.selected(), nil
+		}
+	}
+}
+
+func newChecklist(s MultiSelection) *checklist {
+	checked := make([]bool, len(s.Items))
+	for _, index := range s.Selected {
+		if index >= 0 && index < len(checked) {
+			checked[index] = true
+		}
+	}
+	disabled := make([]bool, len(s.Items))
+	copy(disabled, s.Disabled)
+	return &checklist{
+		label:    s.Label,
+		items:    s.Items,
+		checked:  checked,
+		disabled: disabled,
+		min:      s.Min,
+		max:      s.Max,
+		height:   len(s.Items),
+	}
+}
+
+func (c *checklist) handle(key tty.Key) (bool, bool) {
+	c.status = ""
+	visible := c.visible()
+	switch key.Code {
+	case tty.KeyCtrlC, tty.KeyEsc:
+		return false, true
+	case tty.KeyEnter:
+		return c.validate(), false
+	case tty.KeyUp:
+		c.move(-1)
+	case tty.KeyDown, tty.KeyTab:
+		c.move(1)
+	case tty.KeyPgUp:
+		c.move(-c.height)
+	case tty.KeyPgDn:
+		c.move(c.height)
+	case tty.KeyCtrlA:
+		c.toggleAll(visible)
+	case tty.KeyBackspace:
+		if len(c.filter) > 0 {
+			runes := []rune(c.filter)
+			c.filter = string(runes[:len(runes)-1])
+			c.cursor = 0
+		}
+	case tty.KeyRune:
+		if key.Rune == ' ' {
+			if len(visible) > 0 {
+				c.toggle(visible[c.cursor])
+			}
+			break
+		}
+		c.filter += string(key.Rune)
+		c.cursor = 0
+	}
+	return false, false
+}
+
+func (c *checklist) view(width int) []string {
+	visible := c.visible()
+
+	// header with filter
+	header := Warn(c.label)
+	if c.filter != "" {
+		header += " " + Info("/"+c.filter)
+	}
+	lines := []string{header}
+
+	// scroll cursor into view
+	c.offset = min(c.offset, c.cursor)
+	if c.cursor >= c.offset+c.height {
+		c.offset = c.cursor - c.height + 1
+	}
+
+	// items
+	for i := c.offset; i < len(visible) && i < c.offset+c.height; i++ {
+		box := "[ ]"
+		switch {
+		case c.disabled[visible[i]]:
+			box = "[-]"
+		case c.checked[visible[i]]:
+			box = "[x]"
+		}
+		pointer := "  "
+		if i == c.cursor {
+			pointer = "> "
+		}
+		lines = append(lines, text.Truncate(pointer+box+" "+c.items[visible[i]], width))
+	}
+	if len(visible) == 0 {
+		lines = append(lines, "  no matches")
+	}
+
+	// status
+	status := fmt.Sprintf("%d selected · space toggle · ctrl+a all/none · enter confirm", len(c.selected()))
+	if c.status != "" {
+		status = Fatal(c.status)
+	}
+	lines = append(lines, text.Truncate(status, width))
+
+	return lines
+}
+
+func (c *checklist) visible() []int {
+	filter := strings.ToLower(c.filter)
+	visible := make([]int, 0, len(c.items))
+	for i, item := range c.items {
+		if strings.Contains(strings.ToLower(text.Strip(item)), filter) {
+			visible = append(visible, i)
+		}
+	}
+	return visible
+}
+
+func (c *checklist) move(delta int) {
+	c.cursor = max(min(c.cursor+delta, len(c.visible())-1), 0)
+}
+
+func (c *checklist) toggle(index int) {
+	if c.disabled[index] {
+		return
+	}
+	if !c.checked[index] && c.max > 0 && len(c.selected()) >= c.max {
+		c.status = fmt.Sprintf("select at most %d", c.max)
+		return
+	}
+	c.checked[index] = !c.checked[index]
+}
+
+func (c *checklist) toggleAll(visible []int) {
+
+	// none when all are checked already, disabled items keep their state
+	previous := slices.Clone(c.checked)
+	visible = slices.DeleteFunc(slices.Clone(visible), func(index int) bool {
+		return c.disabled[index]
+	})
+	all := true
+	for _, index := range visible {
+		all = all && c.checked[index]
+	}
+	for _, index := range visible {
+		c.checked[index] = !all
+	}
+
+	// respect the limit
+	if c.max > 0 && len(c.selected()) > c.max {
+		c.checked = previous
+		c.status = fmt.Sprintf("select at most %d", c.max)
+	}
+}
+
+func (c *checklist) validate() bool {
+	if err := checkCount(len(c.selected()), c.min, c.max); err != nil {
+		c.status = err.Error()
+		return false
+	}
+	return true
+}
+
+func (c *checklist) selected() []int {
+	selected := make([]int, 0)
+	for i, checked := range c.checked {
+		if checked {
+			selected = append(selected, i)
+		}
+	}
+	return selected
+}
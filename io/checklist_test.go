@@ -0,0 +1,111 @@
+package io
+
+import (
+	"bytes"
+	"github.com/rollicks-c/term/internal/text"
+	"github.com/rollicks-c/term/internal/tty"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestChecklist(t *testing.T) {
+	c := newChecklist(MultiSelection{
+		Label:    "fruit",
+		Items:    []string{"apple", "banana", "cherry", "date"},
+		Selected: []int{1},
+		Min:      1,
+		Max:      3,
+	})
+	press := func(keys ...tty.Key) (bool, bool) {
+		done, canceled := false, false
+		for _, key := range keys {
+			done, canceled = c.handle(key)
+		}
+		return done, canceled
+	}
+	space := tty.Key{Code: tty.KeyRune, Rune: ' '}
+
+	// toggle under cursor
+	press(space, tty.Key{Code: tty.KeyDown}, space)
+	assert.Equal(t, []int{0}, c.selected())
+	assert.Contains(t, text.Strip(strings.Join(c.view(40), "\n")), "> [ ] banana")
+
+	// filter narrows visible items
+	press(tty.Key{Code: tty.KeyRune, Rune: 'E'})
+	assert.Equal(t, []int{0, 2, 3}, c.visible())
+	press(tty.Key{Code: tty.KeyBackspace})
+	assert.Len(t, c.visible(), 4)
+
+	// all within limit, then none
+	press(tty.Key{Code: tty.KeyCtrlA})
+	assert.Equal(t, []int{0}, c.selected())
+	assert.Equal(t, "select at most 3", c.status)
+	press(tty.Key{Code: tty.KeyRune, Rune: 'a'}, tty.Key{Code: tty.KeyRune, Rune: 'n'})
+	press(tty.Key{Code: tty.KeyCtrlA})
+	assert.Equal(t, []int{0, 1}, c.selected())
+	press(tty.Key{Code: tty.KeyCtrlA})
+	assert.Equal(t, []int{0}, c.selected())
+
+	// minimum on enter
+	press(tty.Key{Code: tty.KeyBackspace}, tty.Key{Code: tty.KeyBackspace}, space)
+	done, _ := press(tty.Key{Code: tty.KeyEnter})
+	assert.False(t, done)
+	assert.Equal(t, "select at least 1", c.status)
+	press(space)
+	done, _ = press(tty.Key{Code: tty.KeyEnter})
+	assert.True(t, done)
+
+	// cancel
+	_, canceled := press(tty.Key{Code: tty.KeyEsc})
+	assert.True(t, canceled)
+}
+
+func TestMultiSelect(t *testing.T) {
+	choices := []Choice[int]{
+		{Label: "one", Value: 1},
+		{Label: "two", Value: 2, Selected: true},
+		{Label: "three", Value: 3},
+	}
+
+	// line input, order follows choices
+	out := &bytes.Buffer{}
+	m := New(strings.NewReader("7\nthree, 1\n\n"), out)
+	values, err := MultiSelect(m, "numbers", choices, WithMinMax(1, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, values)
+	assert.Contains(t, out.String(), "2) [x] two")
+	assert.Contains(t, out.String(), "invalid choice: 7")
+
+	// empty keeps the preselection
+	values, err = MultiSelect(m, "numbers", choices)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2}, values)
+
+	// answers and defaults
+	recorder := NewRecorder()
+	m = New(strings.NewReader(""), out,
+		WithInputPolicy(UseDefaults),
+		WithAnswers(Answers{"numbers": "all"}),
+		WithRecorder(recorder),
+	)
+	values, err = MultiSelect(m, "numbers", choices)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, values)
+	assert.Equal(t, `["one","two","three"]`, recorder.Answers()["numbers"])
+	values, err = MultiSelect(m, "others", choices)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2}, values)
+
+	// explicit keys, recorded prompts may not share them
+	_, err = MultiSelect(m, "Pick numbers", choices, WithChoiceKey("numbers"))
+	assert.Error(t, err)
+	m = New(strings.NewReader(""), out, WithInputPolicy(UseDefaults), WithAnswers(Answers{"numbers": "all"}))
+	values, err = MultiSelect(m, "Pick numbers", choices, WithChoiceKey("numbers"))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, values)
+
+	// defaults violating the minimum
+	_, err = MultiSelect(m, "others", choices, WithMinMax(2, 0))
+	assert.ErrorAs(t, err, &MissingInputError{})
+}
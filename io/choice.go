@@ -0,0 +1,255 @@
+package io
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type Choice[T any] struct {
+	Label       string
+	Description string
+	Value       T
+	Selected    bool
+	Disabled    bool
+}
+
+type ChoiceOption func(config *choiceConfig)
+
+type choiceConfig struct {
+	key    string
+	min    int
+	max    int
+	cursor int
+	format ChoiceFormatter
+}
+
+func WithChoiceKey(key string) ChoiceOption {
+	return func(config *choiceConfig) {
+		config.key = key
+	}
+}
+
+func WithMinMax(min, max int) ChoiceOption {
+	return func(config *choiceConfig) {
+		config.min = min
+		config.max = max
+	}
+}
+
+func WithCursor(index int) ChoiceOption {
+	return func(config *choiceConfig) {
+		config.cursor = index
+	}
+}
+
+func WithFormatter(format ChoiceFormatter) ChoiceOption {
+	return func(config *choiceConfig) {
+		config.format = format
+	}
+}
+
+func Choose[T any](m *Module, prompt string, choices []Choice[T], options ...ChoiceOption) (T, error) {
+	var none T
+
+	// labels, descriptions and state
+	s := Selection{
+		Label:        prompt,
+		Items:        make([]string, len(choices)),
+		Descriptions: make([]string, len(choices)),
+		Disabled:     make([]bool, len(choices)),
+	}
+	for i, choice := range choices {
+		s.Items[i] = choice.Label
+		s.Descriptions[i] = choice.Description
+		s.Disabled[i] = choice.Disabled
+	}
+
+	// cursor starts on an enabled choice
+	config := choiceConfig{}
+	for _, opt := range options {
+		opt(&config)
+	}
+	s.Key, s.Format = config.key, config.format
+	s.Cursor = slices.IndexFunc(choices, func(choice Choice[T]) bool {
+		return !choice.Disabled
+	})
+	if s.Cursor < 0 {
+		return none, fmt.Errorf("no choice available")
+	}
+	if config.cursor > 0 && config.cursor < len(choices) && !choices[config.cursor].Disabled {
+		s.Cursor = config.cursor
+	}
+
+	index, err := m.selectItem(s)
+	if err != nil {
+		return none, err
+	}
+	return choices[index].Value, nil
+}
+
+func MultiSelect[T any](m *Module, prompt string, choices []Choice[T], options ...ChoiceOption) ([]T, error) {
+
+	// labels and preselection
+	s := MultiSelection{
+		Label:    prompt,
+		Items:    make([]string, len(choices)),
+		Disabled: make([]bool, len(choices)),
+	}
+	for i, choice := range choices {
+		s.Items[i] = choice.Label
+		s.Disabled[i] = choice.Disabled
+		if choice.Selected {
+			s.Selected = append(s.Selected, i)
+		}
+	}
+
+	config := choiceConfig{}
+	for _, opt := range options {
+		opt(&config)
+	}
+	s.Key, s.Min, s.Max = config.key, config.min, config.max
+
+	// answered up front, defaults to the preselection
+	q := Question{
+		Key:     s.Key,
+		Label:   prompt,
+		Default: joinChoices(s.Items, s.Selected),
+	}
+	q.Validate = func(value string) error {
+		indices, err := s.parse(value)
+		if err != nil {
+			return err
+		}
+		return checkCount(len(indices), s.Min, s.Max)
+	}
+	answer, ok, err := m.resolve(q)
+	if err != nil {
+		return nil, err
+	}
+
+	// ask
+	var indices []int
+	if ok {
+		indices, err = s.parse(answer)
+	} else {
+		indices, err = m.engine().MultiSelect(s)
+		if err != nil {
+			if answer, err = m.exhausted(q, err); err == nil {
+				indices, err = s.parse(answer)
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// values in choice order
+	slices.Sort(indices)
+	labels := make([]string, len(indices))
+	values := make([]T, len(indices))
+	for i, index := range indices {
+		labels[i] = choices[index].Label
+		values[i] = choices[index].Value
+	}
+	if err := m.record(q, joinLabels(labels)); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func (s MultiSelection) parse(response string) ([]int, error) {
+
+	// keywords, all skips disabled items
+	response = strings.TrimSpace(response)
+	switch strings.ToLower(response) {
+	case "", "none":
+		return []int{}, nil
+	case "all":
+		indices := make([]int, 0, len(s.Items))
+		for i := range s.Items {
+			if !s.disabled(i) {
+				indices = append(indices, i)
+			}
+		}
+		return indices, nil
+	}
+
+	// recorded json arrays, or comma separated where single labels may
+	// contain spaces
+	tokens := strings.Split(response, ",")
+	if strings.HasPrefix(response, "[") {
+		if err := json.Unmarshal([]byte(response), &tokens); err != nil {
+			return nil, fmt.Errorf("invalid choices: %s", response)
+		}
+	} else if len(tokens) == 1 && matchChoice(response, s.Items) < 0 {
+		tokens = strings.Fields(response)
+	}
+
+	// numbers or labels, without duplicates
+	indices := make([]int, 0, len(tokens))
+	for _, token := range tokens {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+		index := matchChoice(token, s.Items)
+		if index < 0 {
+			return nil, fmt.Errorf("invalid choice: %s", token)
+		}
+		if s.disabled(index) {
+			return nil, fmt.Errorf("choice disabled: %s", token)
+		}
+		if !slices.Contains(indices, index) {
+			indices = append(indices, index)
+		}
+	}
+	slices.Sort(indices)
+	return indices, nil
+}
+
+func (s MultiSelection) disabled(index int) bool {
+	return index < len(s.Disabled) && s.Disabled[index]
+}
+
+func matchChoice(token string, items []string) int {
+	for i, item := range items {
+		if strings.EqualFold(item, token) {
+			return i
+		}
+	}
+	if number, err := strconv.Atoi(token); err == nil && number >= 1 && number <= len(items) {
+		return number - 1
+	}
+	return -1
+}
+
+func joinChoices(items []string, indices []int) string {
+	labels := make([]string, len(indices))
+	for i, index := range indices {
+		labels[i] = items[index]
+	}
+	return joinLabels(labels)
+}
+
+func joinLabels(labels []string) string {
+
+	// labels may contain commas, a json array keeps them apart
+	raw, err := json.Marshal(labels)
+	if err != nil {
+		return strings.Join(labels, ",")
+	}
+	return string(raw)
+}
+
+func checkCount(count, min, max int) error {
+	switch {
+	case count < min:
+		return fmt.Errorf("select at least %d", min)
+	case max > 0 && count > max:
+		return fmt.Errorf("select at most %d", max)
+	}
+	return nil
+}
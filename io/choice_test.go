@@ -0,0 +1,85 @@
+package io
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+type shade struct {
+	name string
+	hex  string
+}
+
+func TestChoose(t *testing.T) {
+	choices := []Choice[shade]{
+		{Label: "red", Description: "warm", Value: shade{"red", "#f00"}, Disabled: true},
+		{Label: "green", Value: shade{"green", "#0f0"}},
+		{Label: "blue", Description: "cold", Value: shade{"blue", "#00f"}},
+		{Label: "blue", Value: shade{"navy", "#008"}},
+	}
+
+	// cursor skips disabled, disabled rejected
+	out := &bytes.Buffer{}
+	m := New(strings.NewReader("\nred\n4\n\n"), out)
+	value, err := Choose(m, "color", choices)
+	assert.NoError(t, err)
+	assert.Equal(t, "green", value.name)
+	value, err = Choose(m, "color", choices)
+	assert.NoError(t, err)
+	assert.Equal(t, "navy", value.name)
+	assert.Contains(t, out.String(), "1) red - warm (disabled)")
+	assert.Contains(t, out.String(), "3) blue - cold")
+	assert.Contains(t, out.String(), "choice disabled: red")
+
+	// default cursor
+	value, err = Choose(m, "color", choices, WithCursor(2))
+	assert.NoError(t, err)
+	assert.Equal(t, "blue", value.name)
+
+	// custom formatting
+	out.Reset()
+	m = New(strings.NewReader("2\n"), out)
+	_, err = Choose(m, "color", choices, WithFormatter(func(label, description string, disabled, active bool) string {
+		if disabled {
+			return "~" + label + "~"
+		}
+		return strings.ToUpper(label)
+	}))
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "1) ~red~\n  2) GREEN")
+
+	// answers by label, disabled is invalid
+	m = New(strings.NewReader(""), out, WithAnswers(Answers{"color": "blue", "other": "1"}))
+	value, err = Choose(m, "color", choices)
+	assert.NoError(t, err)
+	assert.Equal(t, "#00f", value.hex)
+	_, err = Choose(m, "other", choices)
+	assert.Error(t, err)
+
+	// nothing to choose
+	_, err = Choose(m, "color", []Choice[int]{{Label: "off", Disabled: true}})
+	assert.Error(t, err)
+}
+
+func TestSelectMenu(t *testing.T) {
+
+	// disabled entries are left out, cursor and picks map back to the items
+	menu, err := newSelectMenu(Selection{
+		Label:    "letter",
+		Items:    []string{"a", "b", "c", "d"},
+		Disabled: []bool{true, false, true, false},
+		Cursor:   3,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "letter (unavailable: a, c)", menu.label)
+	assert.Equal(t, []selectEntry{{Label: "b"}, {Label: "d"}}, menu.entries)
+	assert.Equal(t, 1, menu.cursor)
+	assert.Equal(t, 3, menu.indices[menu.cursor])
+	assert.Equal(t, 1, menu.indices[0])
+
+	// nothing to choose
+	_, err = newSelectMenu(Selection{Items: []string{"a"}, Disabled: []bool{true}})
+	assert.Error(t, err)
+}
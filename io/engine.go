@@ -0,0 +1,387 @@
+package io
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/manifoldco/promptui"
+	"github.com/rollicks-c/term/internal/tty"
+	"io"
+	"maps"
+	"strconv"
+	"strings"
+)
+
+type PromptEngine interface {
+	Ask(q Question) (string, error)
+	Confirm(label string) (bool, error)
+	Select(s Selection) (int, error)
+	MultiSelect(s MultiSelection) ([]int, error)
+}
+
+type Question struct {
+	Key      string
+	Label    string
+	Default  string
+	Secret   bool
+	Validate func(value string) error
+}
+
+type Selection struct {
+	Key          string
+	Label        string
+	Items        []string
+	Descriptions []string
+	Disabled     []bool
+	Cursor       int
+	Format       ChoiceFormatter
+}
+
+type selectEntry struct {
+	Label       string
+	Description string
+}
+
+type ChoiceFormatter func(label, description string, disabled, active bool) string
+
+type MultiSelection struct {
+	Key      string
+	Label    string
+	Items    []string
+	Selected []int
+	Disabled []bool
+	Min      int
+	Max      int
+}
+
+type terminalEngine struct {
+	in  io.Reader
+	out io.Writer
+}
+
+type lineEngine struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+func WithPromptEngine(engine PromptEngine) Option {
+	return func(m *Module) {
+		m.prompts = engine
+	}
+}
+
+func WithLinePrompts() Option {
+	return func(m *Module) {
+		m.prompts = NewLineEngine(m.in, m.out)
+	}
+}
+
+func NewTerminalEngine(in io.Reader, out io.Writer) PromptEngine {
+	return &terminalEngine{
+		in:  in,
+		out: out,
+	}
+}
+
+func NewLineEngine(in io.Reader, out io.Writer) PromptEngine {
+	return &lineEngine{
+		in:  bufio.NewReader(in),
+		out: out,
+	}
+}
+
+func newPromptEngine(in io.Reader, out io.Writer) PromptEngine {
+	if tty.IsTerminal(in) {
+		return NewTerminalEngine(in, out)
+	}
+	return NewLineEngine(in, out)
+}
+
+func (e *terminalEngine) Ask(q Question) (string, error) {
+	prompt := promptui.Prompt{
+		Label:    q.Label,
+		Default:  q.Default,
+		Validate: promptui.ValidateFunc(q.Validate),
+		Stdin:    io.NopCloser(e.in),
+		Stdout:   nopWriteCloser{e.out},
+	}
+	if q.Secret {
+		prompt.Mask = '*'
+	}
+	return prompt.Run()
+}
+
+func (e *terminalEngine) Confirm(label string) (bool, error) {
+	prompt := promptui.Prompt{
+		Label:     label,
+		IsConfirm: true,
+		Stdin:     io.NopCloser(e.in),
+		Stdout:    nopWriteCloser{e.out},
+	}
+	_, err := prompt.Run()
+	if errors.Is(err, promptui.ErrAbort) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+type selectMenu struct {
+	label   string
+	entries []selectEntry
+	indices []int
+	cursor  int
+}
+
+func newSelectMenu(s Selection) (selectMenu, error) {
+
+	// only enabled entries are selectable, disabled ones are listed in the label
+	menu := selectMenu{
+		label:   s.Label,
+		entries: make([]selectEntry, 0, len(s.Items)),
+		indices: make([]int, 0, len(s.Items)),
+	}
+	disabled := make([]string, 0)
+	for i, item := range s.Items {
+		if s.disabled(i) {
+			disabled = append(disabled, item)
+			continue
+		}
+		if i == s.Cursor {
+			menu.cursor = len(menu.entries)
+		}
+		menu.entries = append(menu.entries, selectEntry{
+			Label:       item,
+			Description: s.description(i),
+		})
+		menu.indices = append(menu.indices, i)
+	}
+	if len(menu.entries) == 0 {
+		return menu, fmt.Errorf("no choice available")
+	}
+	if len(disabled) > 0 {
+		menu.label += fmt.Sprintf(" (unavailable: %s)", strings.Join(disabled, ", "))
+	}
+	return menu, nil
+}
+
+func (e *terminalEngine) Select(s Selection) (int, error) {
+
+	menu, err := newSelectMenu(s)
+	if err != nil {
+		return -1, err
+	}
+
+	// entries expose label and description to the formatter
+	funcs := maps.Clone(promptui.FuncMap)
+	funcs["format"] = func(entry selectEntry, active bool) string {
+		return s.formatter()(entry.Label, entry.Description, false, active)
+	}
+	selector := promptui.Select{
+		Label:     menu.label,
+		Items:     menu.entries,
+		CursorPos: menu.cursor,
+		Templates: &promptui.SelectTemplates{
+			Active:   `{{ format . true }}`,
+			Inactive: `{{ format . false }}`,
+			Selected: `{{ "✔" | green }} {{ .Label }}`,
+			FuncMap:  funcs,
+		},
+		Stdin:  io.NopCloser(e.in),
+		Stdout: nopWriteCloser{e.out},
+	}
+	index, _, err := selector.Run()
+	if err != nil {
+		return -1, err
+	}
+	return menu.indices[index], nil
+}
+
+func (e *terminalEngine) MultiSelect(s MultiSelection) ([]int, error) {
+	return runChecklist(e.in, e.out, s)
+}
+
+func (e *lineEngine) Ask(q Question) (string, error) {
+	for {
+
+		// prompt with default
+		label := q.Label
+		if q.Default != "" && !q.Secret {
+			label = fmt.Sprintf("%s [%s]", label, q.Default)
+		}
+		response, err := e.readLine(label + ": ")
+		if err != nil {
+			return "", err
+		}
+		if response == "" {
+			response = q.Default
+		}
+
+		// re-prompt on invalid input
+		if q.Validate != nil {
+			if err := q.Validate(response); err != nil {
+				e.printf("%s\n", Fatal(err.Error()))
+				continue
+			}
+		}
+		return response, nil
+	}
+}
+
+func (e *lineEngine) Confirm(label string) (bool, error) {
+	response, err := e.readLine(label + " (y/n): ")
+	if err != nil {
+		return false, err
+	}
+	response = strings.ToLower(response)
+	return response == "y" || response == "yes", nil
+}
+
+func (e *lineEngine) Select(s Selection) (int, error) {
+
+	// numbered items
+	e.printf("%s\n", s.Label)
+	for i, item := range s.Items {
+		if s.Format != nil {
+			item = s.Format(item, s.description(i), s.disabled(i), false)
+		} else {
+			item = formatChoice(item, s.description(i), s.disabled(i), false)
+		}
+		e.printf("  %d) %s\n", i+1, item)
+	}
+
+	for {
+
+		// number or exact label, empty picks the cursor
+		response, err := e.readLine(fmt.Sprintf("choice [%d]: ", s.Cursor+1))
+		if err != nil {
+			return -1, err
+		}
+		if response == "" {
+			return s.Cursor, nil
+		}
+		index := s.match(response)
+		switch {
+		case index < 0:
+			e.printf("%s\n", Fatal(fmt.Sprintf("invalid choice: %s", response)))
+		case s.disabled(index):
+			e.printf("%s\n", Fatal(fmt.Sprintf("choice disabled: %s", response)))
+		default:
+			return index, nil
+		}
+	}
+}
+
+func (e *lineEngine) MultiSelect(s MultiSelection) ([]int, error) {
+
+	// numbered items with preselection
+	selected := make(map[int]bool, len(s.Selected))
+	for _, index := range s.Selected {
+		selected[index] = true
+	}
+	e.printf("%s\n", s.Label)
+	for i, item := range s.Items {
+		box := "[ ]"
+		switch {
+		case s.disabled(i):
+			box = "[-]"
+		case selected[i]:
+			box = "[x]"
+		}
+		e.printf("  %d) %s %s\n", i+1, box, item)
+	}
+
+	for {
+
+		// numbers or labels, empty keeps the preselection
+		response, err := e.readLine("choices (comma separated, all, none): ")
+		if err != nil {
+			return nil, err
+		}
+		choices := s.Selected
+		if response != "" {
+			choices, err = s.parse(response)
+		}
+		if err == nil {
+			err = checkCount(len(choices), s.Min, s.Max)
+		}
+		if err != nil {
+			e.printf("%s\n", Fatal(err.Error()))
+			continue
+		}
+		return choices, nil
+	}
+}
+
+func (s Selection) match(response string) int {
+
+	// labels first, recorded answers may be numbers themselves
+	for i, item := range s.Items {
+		if item == response {
+			return i
+		}
+	}
+	if choice, err := strconv.Atoi(response); err == nil && choice >= 1 && choice <= len(s.Items) {
+		return choice - 1
+	}
+	return -1
+}
+
+func (s Selection) formatter() ChoiceFormatter {
+	if s.Format != nil {
+		return s.Format
+	}
+	return func(label, description string, disabled, active bool) string {
+		pointer := "  "
+		if active {
+			pointer = Teal("▸ ")
+		}
+		return pointer + formatChoice(label, description, disabled, active)
+	}
+}
+
+func formatChoice(label, description string, disabled, active bool) string {
+	if active && !disabled {
+		label = Teal(label)
+	}
+	if description != "" {
+		label += " - " + description
+	}
+	if disabled {
+		label += " (disabled)"
+	}
+	return label
+}
+
+func (s Selection) description(index int) string {
+	if index < len(s.Descriptions) {
+		return s.Descriptions[index]
+	}
+	return ""
+}
+
+func (s Selection) disabled(index int) bool {
+	return index < len(s.Disabled) && s.Disabled[index]
+}
+
+func (e *lineEngine) readLine(prompt string) (string, error) {
+	e.printf("%s", prompt)
+	line, err := e.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func (e *lineEngine) printf(format string, v ...interface{}) {
+	if _, err := fmt.Fprintf(e.out, format, v...); err != nil {
+		fmt.Print(err)
+	}
+}
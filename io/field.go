@@ -0,0 +1,337 @@
+package io
+
+import (
+	"fmt"
+	"github.com/rollicks-c/term/internal/datetime"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultDateFormat = "2006-01-02"
+
+type fieldKind int
+
+const (
+	stringField fieldKind = iota
+	secretField
+	intField
+	floatField
+	boolField
+	enumField
+	dateField
+	durationField
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+type formField struct {
+	index    int
+	name     string
+	typ      reflect.Type
+	key      string
+	label    string
+	help     string
+	step     string
+	kind     fieldKind
+	options  []string
+	layout   string
+	def      string
+	hasDef   bool
+	required bool
+	min      *float64
+	max      *float64
+	pattern  *regexp.Regexp
+	when     *fieldCondition
+}
+
+type fieldCondition struct {
+	field  string
+	values []string
+	negate bool
+}
+
+func formFields(t reflect.Type) ([]formField, error) {
+	fields := make([]formField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup("form")
+		if !ok || tag == "-" || !sf.IsExported() {
+			continue
+		}
+		f, err := newFormField(i, sf, tag)
+		if err != nil {
+			return nil, fmt.Errorf("invalid form field %s: %w", sf.Name, err)
+		}
+		fields = append(fields, f)
+	}
+
+	// conditions refer to known fields
+	for _, f := range fields {
+		if f.when == nil {
+			continue
+		}
+		if !slices.ContainsFunc(fields, func(other formField) bool { return other.name == f.when.field }) {
+			return nil, fmt.Errorf("invalid form field %s: unknown condition field %s", f.name, f.when.field)
+		}
+	}
+	return fields, nil
+}
+
+func newFormField(index int, sf reflect.StructField, tag string) (formField, error) {
+
+	// key and flags from the form tag
+	name, flags, _ := strings.Cut(tag, ",")
+	f := formField{
+		index:  index,
+		name:   sf.Name,
+		typ:    sf.Type,
+		key:    name,
+		label:  sf.Tag.Get("label"),
+		help:   sf.Tag.Get("help"),
+		step:   sf.Tag.Get("step"),
+		layout: sf.Tag.Get("format"),
+	}
+	f.def, f.hasDef = sf.Tag.Lookup("default")
+	if f.key == "" {
+		f.key = promptKey(sf.Name)
+	}
+	if f.label == "" {
+		f.label = sf.Name
+	}
+	if f.layout == "" {
+		f.layout = defaultDateFormat
+	}
+
+	// kind by type, enums by options
+	switch {
+	case sf.Type == timeType:
+		f.kind = dateField
+	case sf.Type == durationType:
+		f.kind = durationField
+	case sf.Tag.Get("options") != "" && sf.Type.Kind() == reflect.String:
+		f.kind = enumField
+		f.options = strings.Split(sf.Tag.Get("options"), ",")
+	case sf.Type.Kind() == reflect.String && slices.Contains(strings.Split(flags, ","), "secret"):
+		f.kind = secretField
+	case sf.Type.Kind() == reflect.String:
+		f.kind = stringField
+	case sf.Type.Kind() == reflect.Bool:
+		f.kind = boolField
+	case sf.Type.Kind() >= reflect.Int && sf.Type.Kind() <= reflect.Int64:
+		f.kind = intField
+	case sf.Type.Kind() == reflect.Float32 || sf.Type.Kind() == reflect.Float64:
+		f.kind = floatField
+	default:
+		return f, fmt.Errorf("unsupported type %s", sf.Type)
+	}
+
+	// validation rules
+	if err := f.parseRules(sf.Tag.Get("validate")); err != nil {
+		return f, err
+	}
+	if pattern := sf.Tag.Get("pattern"); pattern != "" {
+		exp, err := regexp.Compile(pattern)
+		if err != nil {
+			return f, err
+		}
+		f.pattern = exp
+	}
+
+	// shown only when another field matches
+	if when := sf.Tag.Get("when"); when != "" {
+		f.when = parseCondition(when)
+	}
+
+	return f, nil
+}
+
+func (f *formField) parseRules(rules string) error {
+	for _, rule := range strings.Split(rules, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch name {
+		case "":
+		case "required":
+			f.required = true
+		case "min", "max":
+			limit, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return fmt.Errorf("invalid rule %s: %w", rule, err)
+			}
+			if name == "min" {
+				f.min = &limit
+			} else {
+				f.max = &limit
+			}
+		default:
+			return fmt.Errorf("unknown rule %s", rule)
+		}
+	}
+	return nil
+}
+
+func parseCondition(when string) *fieldCondition {
+	if field, values, ok := strings.Cut(when, "!="); ok {
+		return &fieldCondition{field: field, values: strings.Split(values, "|"), negate: true}
+	}
+	if field, values, ok := strings.Cut(when, "="); ok {
+		return &fieldCondition{field: field, values: strings.Split(values, "|")}
+	}
+	return &fieldCondition{field: when}
+}
+
+func (c *fieldCondition) holds(target reflect.Value, fields []formField) bool {
+	i := slices.IndexFunc(fields, func(f formField) bool { return f.name == c.field })
+	other := fields[i]
+	value := target.Field(other.index)
+
+	// set at all
+	if len(c.values) == 0 {
+		return !value.IsZero()
+	}
+	return slices.Contains(c.values, other.format(value)) != c.negate
+}
+
+func (f formField) parse(response string) (reflect.Value, error) {
+	response = strings.TrimSpace(response)
+	if response == "" && f.required {
+		return reflect.Value{}, fmt.Errorf("value required")
+	}
+
+	switch f.kind {
+	case stringField, secretField:
+		length := float64(len([]rune(response)))
+		if err := f.checkRange(length, "length"); err != nil {
+			return reflect.Value{}, err
+		}
+		if f.pattern != nil && response != "" && !f.pattern.MatchString(response) {
+			return reflect.Value{}, fmt.Errorf("value must match %s", f.pattern)
+		}
+		return reflect.ValueOf(response), nil
+
+	case enumField:
+		if response != "" && !slices.Contains(f.options, response) {
+			return reflect.Value{}, fmt.Errorf("value must be one of %s", strings.Join(f.options, ", "))
+		}
+		return reflect.ValueOf(response), nil
+
+	case intField:
+		if response == "" {
+			return reflect.ValueOf(int64(0)), nil
+		}
+		value, err := strconv.ParseInt(response, 10, 64)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid number: %s", response)
+		}
+		if reflect.Zero(f.typ).OverflowInt(value) {
+			return reflect.Value{}, fmt.Errorf("number out of range: %s", response)
+		}
+		return reflect.ValueOf(value), f.checkRange(float64(value), "value")
+
+	case floatField:
+		if response == "" {
+			return reflect.ValueOf(0.0), nil
+		}
+		value, err := strconv.ParseFloat(response, 64)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid number: %s", response)
+		}
+		if reflect.Zero(f.typ).OverflowFloat(value) {
+			return reflect.Value{}, fmt.Errorf("number out of range: %s", response)
+		}
+		return reflect.ValueOf(value), f.checkRange(value, "value")
+
+	case boolField:
+		switch strings.ToLower(response) {
+		case "y", "yes", "true":
+			return reflect.ValueOf(true), nil
+		case "", "n", "no", "false":
+			return reflect.ValueOf(false), nil
+		}
+		return reflect.Value{}, fmt.Errorf("answer y or n")
+
+	case dateField:
+		if response == "" {
+			return reflect.ValueOf(time.Time{}), nil
+		}
+		parser := datetime.NewParser()
+		date, err := parser.DateParser(f.layout)(response)
+		if err != nil {
+			date, err = parser.ParseRelativeDay(response)
+		}
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid date, use %s or relative days like 1+", f.layout)
+		}
+		return reflect.ValueOf(datetime.DateOnly(date)), nil
+
+	case durationField:
+		if response == "" {
+			return reflect.ValueOf(time.Duration(0)), nil
+		}
+		duration, err := time.ParseDuration(response)
+		if err != nil {
+			duration, err = datetime.NewParser().ParseDuration(response)
+		}
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid duration: %s", response)
+		}
+		return reflect.ValueOf(duration), nil
+	}
+	return reflect.Value{}, fmt.Errorf("unsupported field %s", f.name)
+}
+
+func (f formField) checkRange(value float64, subject string) error {
+	switch {
+	case f.min != nil && value < *f.min:
+		return fmt.Errorf("%s must be at least %s", subject, formatNumber(*f.min))
+	case f.max != nil && value > *f.max:
+		return fmt.Errorf("%s must be at most %s", subject, formatNumber(*f.max))
+	}
+	return nil
+}
+
+func (f formField) set(target reflect.Value, value reflect.Value) {
+	field := target.Field(f.index)
+	switch f.kind {
+	case intField:
+		field.SetInt(value.Int())
+	case floatField:
+		field.SetFloat(value.Float())
+	default:
+		field.Set(value.Convert(field.Type()))
+	}
+}
+
+func (f formField) format(value reflect.Value) string {
+	switch f.kind {
+	case intField:
+		return strconv.FormatInt(value.Int(), 10)
+	case floatField:
+		return formatNumber(value.Float())
+	case boolField:
+		return yesNo(value.Bool())
+	case dateField:
+		date := value.Interface().(time.Time)
+		if date.IsZero() {
+			return ""
+		}
+		return date.Format(f.layout)
+	case durationField:
+		duration := time.Duration(value.Int())
+		if duration == 0 {
+			return ""
+		}
+		return duration.String()
+	}
+	return value.String()
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,440 @@
+package io
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/rollicks-c/term/internal/text"
+	"github.com/rollicks-c/term/internal/tty"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	defaultDebounce = 150 * time.Millisecond
+	tabWidth        = 8
+)
+
+type FinderItem struct {
+	Label string
+	Value any
+}
+
+type SearchFunc func(query string) ([]FinderItem, error)
+
+type PreviewFunc func(item FinderItem) string
+
+type FinderOption func(config *finderConfig)
+
+type finderConfig struct {
+	key      string
+	query    string
+	debounce time.Duration
+	preview  PreviewFunc
+}
+
+type finderMatch struct {
+	item      FinderItem
+	matched   bool
+	score     int
+	positions []int
+}
+
+type finder struct {
+	label    string
+	query    string
+	searched string
+	items    []FinderItem
+	matches  []finderMatch
+	cursor   int
+	offset   int
+	height   int
+	preview  PreviewFunc
+	previews map[int]string
+	status   string
+}
+
+func WithFinderKey(key string) FinderOption {
+	return func(config *finderConfig) {
+		config.key = key
+	}
+}
+
+func WithQuery(query string) FinderOption {
+	return func(config *finderConfig) {
+		config.query = query
+	}
+}
+
+func WithDebounce(delay time.Duration) FinderOption {
+	return func(config *finderConfig) {
+		config.debounce = delay
+	}
+}
+
+func WithPreview(preview PreviewFunc) FinderOption {
+	return func(config *finderConfig) {
+		config.preview = preview
+	}
+}
+
+// Find re-queries search once typing pauses. Terminals that cannot be polled
+// (non-unix platforms) never see that pause, there the initial results are
+// only filtered locally.
+func (m Module) Find(label string, search SearchFunc, options ...FinderOption) (any, error) {
+
+	// init config
+	config := finderConfig{debounce: defaultDebounce}
+	for _, opt := range options {
+		opt(&config)
+	}
+
+	// answered up front
+	q := Question{Key: config.key, Label: label, Default: config.query}
+	answer, ok, err := m.resolve(q)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		item, err := findAnswer(search, answer)
+		if err != nil {
+			return nil, fmt.Errorf("invalid answer for %s: %w", q.key(), err)
+		}
+		if err := m.record(q, item.Label); err != nil {
+			return nil, err
+		}
+		return item.Value, nil
+	}
+
+	// no terminal, choose from the initial results
+	if !tty.IsTerminal(m.in) {
+		items, err := search(config.query)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			return nil, fmt.Errorf("no item found for [%s]", config.query)
+		}
+		s := Selection{Key: config.key, Label: label, Items: make([]string, len(items))}
+		for i, item := range items {
+			s.Items[i] = item.Label
+		}
+		index, err := m.selectItem(s)
+		if err != nil {
+			return nil, err
+		}
+		return items[index].Value, nil
+	}
+
+	item, err := m.runFinder(label, search, config)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.record(q, item.Label); err != nil {
+		return nil, err
+	}
+	return item.Value, nil
+}
+
+func (m Module) runFinder(label string, search SearchFunc, config finderConfig) (FinderItem, error) {
+
+	// initial results
+	f := newFinder(label, config)
+	items, err := search(f.query)
+	if err != nil {
+		return FinderItem{}, err
+	}
+	f.setItems(f.query, items)
+
+	// enter full screen
+	term, err := tty.Open(m.in, m.out)
+	if err != nil {
+		return FinderItem{}, err
+	}
+	defer func() {
+		_ = term.Close()
+	}()
+
+	// interact, the provider is queried once typing pauses
+	changed := time.Time{}
+	for {
+		width, height := term.Size()
+		f.height = max(height-2, 1)
+		if err := term.Draw(f.view(width)); err != nil {
+			return FinderItem{}, err
+		}
+		key, ok, err := term.ReadKeyTimeout(f.timeout(config.debounce, changed))
+		if err != nil {
+			return FinderItem{}, err
+		}
+		if !ok {
+			if !f.stale() {
+				continue
+			}
+			items, err := search(f.query)
+			if err != nil {
+				f.status = err.Error()
+				f.searched = f.query
+				continue
+			}
+			f.setItems(f.query, items)
+			continue
+		}
+		query := f.query
+		done, canceled := f.handle(key)
+		if canceled {
+			return FinderItem{}, ErrCanceled
+		}
+		if done {
+			return f.matches[f.cursor].item, nil
+		}
+		if f.query != query {
+			changed = time.Now()
+		}
+	}
+}
+
+func findAnswer(search SearchFunc, answer string) (FinderItem, error) {
+	items, err := search(answer)
+	if err != nil {
+		return FinderItem{}, err
+	}
+	for _, item := range items {
+		if strings.EqualFold(item.Label, answer) {
+			return item, nil
+		}
+	}
+	switch len(items) {
+	case 0:
+		return FinderItem{}, fmt.Errorf("no item found for [%s]", answer)
+	case 1:
+		return items[0], nil
+	}
+	return FinderItem{}, fmt.Errorf("ambiguous answer [%s]", answer)
+}
+
+func newFinder(label string, config finderConfig) *finder {
+	return &finder{
+		label:    label,
+		query:    config.query,
+		height:   1,
+		preview:  config.preview,
+		previews: make(map[int]string),
+	}
+}
+
+func (f *finder) handle(key tty.Key) (bool, bool) {
+	f.status = ""
+	switch key.Code {
+	case tty.KeyCtrlC, tty.KeyEsc:
+		return false, true
+	case tty.KeyEnter:
+		if len(f.matches) == 0 {
+			f.status = "no match"
+			return false, false
+		}
+		return true, false
+	case tty.KeyUp:
+		f.move(-1)
+	case tty.KeyDown, tty.KeyTab:
+		f.move(1)
+	case tty.KeyPgUp:
+		f.move(-f.height)
+	case tty.KeyPgDn:
+		f.move(f.height)
+	case tty.KeyBackspace:
+		if len(f.query) > 0 {
+			runes := []rune(f.query)
+			f.setQuery(string(runes[:len(runes)-1]))
+		}
+	case tty.KeyRune:
+		f.setQuery(f.query + string(key.Rune))
+	}
+	return false, false
+}
+
+func (f *finder) setQuery(query string) {
+	f.query = query
+	f.rank()
+}
+
+func (f *finder) setItems(query string, items []FinderItem) {
+	f.searched = query
+	f.items = items
+	f.rank()
+}
+
+func (f *finder) stale() bool {
+	return f.searched != f.query
+}
+
+func (f *finder) timeout(debounce time.Duration, changed time.Time) time.Duration {
+
+	// fresh results wait for the next key, stale ones for the typing pause
+	if !f.stale() {
+		return -1
+	}
+	return max(debounce-time.Since(changed), 0)
+}
+
+func (f *finder) rank() {
+
+	// fresh results are kept even when the provider matched on something
+	// other than the label, stale ones are narrowed down while typing
+	f.matches = f.matches[:0]
+	for _, item := range f.items {
+		score, positions, ok := fuzzyMatch(f.query, text.Strip(item.Label))
+		if !ok && f.stale() {
+			continue
+		}
+		f.matches = append(f.matches, finderMatch{item: item, matched: ok, score: score, positions: positions})
+	}
+	slices.SortStableFunc(f.matches, func(a, b finderMatch) int {
+		if a.matched != b.matched {
+			if a.matched {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(b.score, a.score)
+	})
+
+	f.cursor, f.offset = 0, 0
+	clear(f.previews)
+}
+
+func (f *finder) move(delta int) {
+	f.cursor = max(min(f.cursor+delta, len(f.matches)-1), 0)
+}
+
+func (f *finder) view(width int) []string {
+
+	// prompt with counts
+	count := fmt.Sprintf("%d/%d", len(f.matches), len(f.items))
+	if f.stale() {
+		count += " …"
+	}
+	lines := []string{
+		text.Truncate(Warn(f.label)+" > "+f.query, width),
+		text.Truncate(Info(count), width),
+	}
+
+	// list and preview side by side
+	listWidth := width
+	if f.preview != nil && width >= 40 {
+		listWidth = width / 2
+	}
+	list := f.list(listWidth)
+	if listWidth < width {
+		previews := f.previewLines(width - listWidth - 3)
+		for i := range list {
+			list[i] = text.Pad(list[i], listWidth) + " │ " + previews[i]
+		}
+	}
+	lines = append(lines, list...)
+
+	if f.status != "" {
+		lines[1] = text.Truncate(Fatal(f.status), width)
+	}
+	return lines
+}
+
+func (f *finder) list(width int) []string {
+
+	// scroll cursor into view
+	f.offset = min(f.offset, f.cursor)
+	if f.cursor >= f.offset+f.height {
+		f.offset = f.cursor - f.height + 1
+	}
+
+	lines := make([]string, f.height)
+	for i := range lines {
+		index := f.offset + i
+		if index >= len(f.matches) {
+			continue
+		}
+		pointer := "  "
+		if index == f.cursor {
+			pointer = Info("> ")
+		}
+		match := f.matches[index]
+		lines[i] = text.Truncate(pointer+highlight(text.Strip(match.item.Label), match.positions), width)
+	}
+	return lines
+}
+
+func (f *finder) previewLines(width int) []string {
+	lines := make([]string, f.height)
+	if len(f.matches) == 0 {
+		return lines
+	}
+
+	// previews are generated once per item
+	preview, ok := f.previews[f.cursor]
+	if !ok {
+		preview = f.preview(f.matches[f.cursor].item)
+		f.previews[f.cursor] = preview
+	}
+	for i, line := range strings.Split(text.ExpandTabs(preview, tabWidth), "\n") {
+		if i >= len(lines) {
+			break
+		}
+		lines[i] = text.Truncate(line, width)
+	}
+	return lines
+}
+
+func highlight(label string, positions []int) string {
+	if len(positions) == 0 {
+		return label
+	}
+	out := strings.Builder{}
+	for i, r := range []rune(label) {
+		if slices.Contains(positions, i) {
+			out.WriteString(Teal(string(r)))
+			continue
+		}
+		out.WriteRune(r)
+	}
+	return out.String()
+}
+
+func fuzzyMatch(query, label string) (int, []int, bool) {
+	if query == "" {
+		return 0, nil, true
+	}
+
+	// query runes in order, case-insensitive
+	runes := []rune(label)
+	pattern := []rune(strings.ToLower(query))
+	positions := make([]int, 0, len(pattern))
+	score, last := 0, -1
+	for i, r := range runes {
+		if len(positions) == len(pattern) {
+			break
+		}
+		if unicode.ToLower(r) != pattern[len(positions)] {
+			continue
+		}
+
+		// consecutive runes and word starts weigh most, gaps cost
+		score++
+		switch {
+		case last >= 0 && i == last+1:
+			score += 8
+		case i == 0 || !unicode.IsLetter(runes[i-1]) && !unicode.IsDigit(runes[i-1]):
+			score += 6
+		case last >= 0:
+			score -= min(i-last-1, 5)
+		}
+		positions = append(positions, i)
+		last = i
+	}
+	if len(positions) < len(pattern) {
+		return 0, nil, false
+	}
+
+	// shorter labels win ties
+	return score*100 - len(runes), positions, true
+}
@@ -0,0 +1,143 @@
+package io
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/rollicks-c/term/internal/text"
+	"github.com/rollicks-c/term/internal/tty"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+var projects = []FinderItem{
+	{Label: "billing-service", Value: 1},
+	{Label: "web-app", Value: 2},
+	{Label: "bill", Value: 3},
+	{Label: "infra", Value: 4},
+}
+
+func searchProjects(query string) ([]FinderItem, error) {
+	found := make([]FinderItem, 0)
+	for _, item := range projects {
+		if strings.Contains(item.Label, query) {
+			found = append(found, item)
+		}
+	}
+	return found, nil
+}
+
+func TestFuzzyMatch(t *testing.T) {
+
+	// subsequence with positions
+	score, positions, ok := fuzzyMatch("bs", "billing-service")
+	assert.True(t, ok)
+	assert.Positive(t, score)
+	assert.Equal(t, []int{0, 8}, positions)
+	_, _, ok = fuzzyMatch("sb", "billing-service")
+	assert.False(t, ok)
+
+	// weak matches in long labels score below zero but still match
+	score, _, ok = fuzzyMatch("xz", "x"+strings.Repeat("a", 400)+"z")
+	assert.True(t, ok)
+	assert.Negative(t, score)
+
+	// consecutive and shorter beats scattered
+	exact, _, _ := fuzzyMatch("bill", "bill")
+	prefix, _, _ := fuzzyMatch("bill", "billing-service")
+	scattered, _, _ := fuzzyMatch("bill", "b-i-l-l")
+	assert.Greater(t, exact, prefix)
+	assert.Greater(t, prefix, scattered)
+}
+
+func TestFinder(t *testing.T) {
+	f := newFinder("project", finderConfig{
+		preview: func(item FinderItem) string {
+			return fmt.Sprintf("id: %v", item.Value)
+		},
+	})
+	f.height = 3
+	items, _ := searchProjects("")
+	f.setItems("", items)
+	assert.Len(t, f.matches, 4)
+
+	// typing narrows stale results and ranks them
+	f.handle(tty.Key{Code: tty.KeyRune, Rune: 'b'})
+	f.handle(tty.Key{Code: tty.KeyRune, Rune: 'i'})
+	assert.True(t, f.stale())
+	assert.Equal(t, "bill", f.matches[0].item.Label)
+	assert.Len(t, f.matches, 2)
+
+	// fresh results, highlight and preview
+	items, _ = searchProjects(f.query)
+	f.setItems(f.query, items)
+	assert.False(t, f.stale())
+	f.handle(tty.Key{Code: tty.KeyDown})
+	view := f.view(60)
+	assert.Contains(t, view[2], Teal("b")+Teal("i")+"ll")
+	assert.Contains(t, text.Strip(view[3]), "> billing-service")
+	assert.Contains(t, text.Strip(view[2]), "│ id: 1")
+
+	// select
+	done, _ := f.handle(tty.Key{Code: tty.KeyEnter})
+	assert.True(t, done)
+	assert.Equal(t, 1, f.matches[f.cursor].item.Value)
+
+	// nothing to select
+	f.handle(tty.Key{Code: tty.KeyRune, Rune: 'x'})
+	done, _ = f.handle(tty.Key{Code: tty.KeyEnter})
+	assert.False(t, done)
+	assert.Equal(t, "no match", f.status)
+	_, canceled := f.handle(tty.Key{Code: tty.KeyCtrlC})
+	assert.True(t, canceled)
+}
+
+func TestFind(t *testing.T) {
+
+	// without terminal
+	out := &bytes.Buffer{}
+	m := New(strings.NewReader("2\n"), out)
+	value, err := m.Find("project", searchProjects, WithQuery("bill"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, value)
+
+	// answers by exact or unique label
+	m = New(strings.NewReader(""), out, WithAnswers(Answers{"project": "bill", "other": "web", "any": "i"}))
+	value, err = m.Find("project", searchProjects)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, value)
+	value, err = m.Find("other", searchProjects)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, value)
+	_, err = m.Find("any", searchProjects)
+	assert.ErrorContains(t, err, "ambiguous")
+}
+
+func TestFinderLongLabels(t *testing.T) {
+	long := "x" + strings.Repeat("a", 400) + "z"
+	f := newFinder("project", finderConfig{})
+	f.setItems("", []FinderItem{{Label: long}, {Label: "other"}})
+
+	// stale results keep weak matches
+	f.handle(tty.Key{Code: tty.KeyRune, Rune: 'x'})
+	f.handle(tty.Key{Code: tty.KeyRune, Rune: 'z'})
+	assert.Len(t, f.matches, 1)
+	assert.Equal(t, long, f.matches[0].item.Label)
+
+	// fresh non-matches rank last
+	f.setItems("xz", []FinderItem{{Label: "other"}, {Label: long}})
+	assert.Equal(t, long, f.matches[0].item.Label)
+}
+
+func TestFinderTimeout(t *testing.T) {
+	f := newFinder("project", finderConfig{})
+	items, _ := searchProjects("")
+	f.setItems("", items)
+
+	// fresh results block, stale ones wait for the pause
+	assert.Equal(t, time.Duration(-1), f.timeout(time.Second, time.Now()))
+	f.handle(tty.Key{Code: tty.KeyRune, Rune: 'b'})
+	assert.Greater(t, f.timeout(time.Second, time.Now()), time.Duration(0))
+	assert.Equal(t, time.Duration(0), f.timeout(time.Second, time.Now().Add(-2*time.Second)))
+}
@@ -0,0 +1,307 @@
+package io
+
+import (
+	"fmt"
+	"github.com/rollicks-c/term/internal/text"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+const (
+	formBack   = "<"
+	backItem   = "« back"
+	submitItem = "Submit"
+	cancelItem = "Cancel"
+)
+
+type FormOption func(config *formConfig)
+
+type formConfig struct {
+	title  string
+	review bool
+}
+
+type form struct {
+	m        Module
+	config   formConfig
+	target   reflect.Value
+	fields   []formField
+	steps    []string
+	answered []bool
+}
+
+func WithFormTitle(title string) FormOption {
+	return func(config *formConfig) {
+		config.title = title
+	}
+}
+
+func WithReview(state bool) FormOption {
+	return func(config *formConfig) {
+		config.review = state
+	}
+}
+
+func (m Module) Form(target any, options ...FormOption) error {
+
+	// init config
+	config := formConfig{
+		title:  "Review",
+		review: true,
+	}
+	for _, opt := range options {
+		opt(&config)
+	}
+
+	// struct fields with form tags
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("form target must be a struct pointer, got %T", target)
+	}
+	fields, err := formFields(v.Elem().Type())
+	if err != nil {
+		return err
+	}
+	f := &form{
+		m:        m,
+		config:   config,
+		target:   v.Elem(),
+		fields:   fields,
+		answered: make([]bool, len(fields)),
+	}
+	if err := f.applyDefaults(); err != nil {
+		return err
+	}
+	for _, field := range fields {
+		if field.step != "" && !slices.Contains(f.steps, field.step) {
+			f.steps = append(f.steps, field.step)
+		}
+	}
+
+	// fill, then review until submitted
+	for {
+		if err := f.fill(); err != nil {
+			return err
+		}
+		if !config.review {
+			return f.resetHidden()
+		}
+		edit, err := f.review()
+		if err != nil {
+			return err
+		}
+		if edit < 0 {
+			return f.resetHidden()
+		}
+		f.answered[edit] = false
+	}
+}
+
+func (f *form) applyDefaults() error {
+
+	// preset values win over tag defaults
+	for _, field := range f.fields {
+		if !field.hasDef || !f.target.Field(field.index).IsZero() {
+			continue
+		}
+		if err := f.reset(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *form) resetHidden() error {
+
+	// hidden fields were never confirmed, resetting may hide others
+	for changed := true; changed; {
+		changed = false
+		for _, field := range f.fields {
+			if f.visible(field) {
+				continue
+			}
+			before := f.target.Field(field.index).Interface()
+			if err := f.reset(field); err != nil {
+				return err
+			}
+			changed = changed || !reflect.DeepEqual(before, f.target.Field(field.index).Interface())
+		}
+	}
+	return nil
+}
+
+func (f *form) reset(field formField) error {
+	target := f.target.Field(field.index)
+	if !field.hasDef {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+	value, err := field.parse(field.def)
+	if err != nil {
+		return fmt.Errorf("invalid default for %s: %w", field.name, err)
+	}
+	field.set(f.target, value)
+	return nil
+}
+
+func (f *form) fill() error {
+
+	// ask visible fields not answered yet, "<" returns to the previous one
+	history := make([]int, 0, len(f.fields))
+	step := ""
+	for i := 0; i < len(f.fields); i++ {
+		field := f.fields[i]
+		if f.answered[i] || !f.visible(field) {
+			continue
+		}
+		if field.step != step {
+			step = field.step
+			if step != "" {
+				f.m.InfoF("Step %d/%d: %s\n", slices.Index(f.steps, step)+1, len(f.steps), step)
+			}
+		}
+
+		back, err := f.ask(field, len(history) > 0)
+		if err != nil {
+			return err
+		}
+		if back {
+			previous := history[len(history)-1]
+			history = history[:len(history)-1]
+			f.answered[previous] = false
+			i, step = previous-1, ""
+			continue
+		}
+		f.answered[i] = true
+		history = append(history, i)
+	}
+	return nil
+}
+
+func (f *form) ask(field formField, canGoBack bool) (bool, error) {
+
+	// help unless answered up front
+	if _, ok := f.m.answer(field.key); !ok && field.help != "" && f.m.policy == AskAlways {
+		f.m.PrintF(Default, "%s\n", field.help)
+	}
+	current := field.format(f.target.Field(field.index))
+
+	// enums select, with a way back
+	if field.kind == enumField {
+		s := Selection{
+			Key:    field.key,
+			Label:  field.label,
+			Items:  slices.Clone(field.options),
+			Cursor: max(slices.Index(field.options, current), 0),
+		}
+		if canGoBack {
+			s.Items = append(s.Items, backItem)
+		}
+		index, err := f.m.selectItem(s)
+		if err != nil {
+			return false, err
+		}
+		if s.Items[index] == backItem {
+			return true, nil
+		}
+		field.set(f.target, reflect.ValueOf(s.Items[index]))
+		return false, nil
+	}
+
+	// everything else is typed and parsed
+	q := Question{
+		Key:     field.key,
+		Label:   field.label,
+		Default: current,
+		Secret:  field.kind == secretField,
+		Validate: func(value string) error {
+			if canGoBack && value == formBack {
+				return nil
+			}
+			_, err := field.parse(value)
+			return err
+		},
+	}
+	if field.kind == boolField {
+		q.Label += " (y/n)"
+	}
+	if canGoBack {
+		q.Label += " [< back]"
+	}
+	answer, err := f.m.ask(q)
+	if err != nil {
+		return false, err
+	}
+	if canGoBack && answer == formBack {
+		return true, nil
+	}
+	value, err := field.parse(answer)
+	if err != nil {
+		return false, err
+	}
+	field.set(f.target, value)
+	return false, nil
+}
+
+func (f *form) review() (int, error) {
+
+	// summary of visible fields by step
+	f.m.Box(f.summary(), WithBoxTitle(f.config.title))
+
+	// submit, edit a field or cancel
+	s := Selection{
+		Key:   promptKey(f.config.title),
+		Label: f.config.title,
+		Items: []string{submitItem},
+	}
+	editable := make([]int, 0, len(f.fields))
+	for i, field := range f.fields {
+		if f.visible(field) {
+			s.Items = append(s.Items, "Edit "+field.label)
+			editable = append(editable, i)
+		}
+	}
+	s.Items = append(s.Items, cancelItem)
+	index, err := f.m.selectItem(s)
+	if err != nil {
+		return -1, err
+	}
+
+	switch s.Items[index] {
+	case submitItem:
+		return -1, nil
+	case cancelItem:
+		return -1, ErrCanceled
+	}
+	return editable[index-1], nil
+}
+
+func (f *form) summary() string {
+	width := 0
+	for _, field := range f.fields {
+		width = max(width, text.Width(field.label))
+	}
+
+	lines := make([]string, 0, len(f.fields))
+	step := ""
+	for _, field := range f.fields {
+		if !f.visible(field) {
+			continue
+		}
+		if field.step != step && field.step != "" {
+			lines = append(lines, Info(field.step))
+		}
+		step = field.step
+		value := field.format(f.target.Field(field.index))
+		if field.kind == secretField && value != "" {
+			value = "••••••"
+		}
+		lines = append(lines, text.Pad(field.label, width)+"  "+value)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (f *form) visible(field formField) bool {
+	return field.when == nil || field.when.holds(f.target, f.fields)
+}
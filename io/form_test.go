@@ -0,0 +1,101 @@
+package io
+
+import (
+	"bytes"
+	"github.com/rollicks-c/term/internal/text"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+type signup struct {
+	Name     string        `form:"name" label:"Name" validate:"required,min=2" step:"Account"`
+	Password string        `form:"password,secret" label:"Password" validate:"required" step:"Account"`
+	Plan     string        `form:"plan" label:"Plan" options:"free,pro,team" default:"free" step:"Billing"`
+	Seats    int           `form:"seats" label:"Seats" default:"1" validate:"min=1,max=50" when:"Plan=team" step:"Billing"`
+	Rate     float64       `form:"rate" label:"Rate" step:"Billing"`
+	Trial    bool          `form:"trial" label:"Trial" when:"Plan!=free" help:"Try before you buy" step:"Billing"`
+	Start    time.Time     `form:"start" label:"Start" step:"Billing"`
+	Timeout  time.Duration `form:"timeout" label:"Timeout" default:"30m" step:"Billing"`
+	Internal string
+}
+
+func TestForm(t *testing.T) {
+
+	// invalid name, back from password, review edits the plan
+	input := []string{
+		"a", "al", "<", "alice", "s3cret",
+		"2", "1.5", "y", "2025-03-01", "",
+		"4",
+		"3", "10",
+		"1",
+	}
+	out := &bytes.Buffer{}
+	m := New(strings.NewReader(strings.Join(input, "\n")+"\n"), out)
+	s := signup{}
+	assert.NoError(t, m.Form(&s))
+	assert.Equal(t, "alice", s.Name)
+	assert.Equal(t, "s3cret", s.Password)
+	assert.Equal(t, "team", s.Plan)
+	assert.Equal(t, 10, s.Seats)
+	assert.Equal(t, 1.5, s.Rate)
+	assert.True(t, s.Trial)
+	assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), s.Start)
+	assert.Equal(t, 30*time.Minute, s.Timeout)
+
+	plain := text.Strip(out.String())
+	assert.Contains(t, plain, "length must be at least 2")
+	assert.Contains(t, plain, "Step 1/2: Account")
+	assert.Contains(t, plain, "Step 2/2: Billing")
+	assert.Contains(t, plain, "Try before you buy")
+	assert.Contains(t, plain, "Password  ••••••")
+	assert.NotContains(t, plain, "s3cret")
+	assert.Contains(t, plain, "Seats     10")
+
+	// fields hidden after editing fall back to defaults
+	input = []string{
+		"alice", "s3cret",
+		"3", "10", "", "y", "", "",
+		"4",
+		"1",
+		"1",
+	}
+	m = New(strings.NewReader(strings.Join(input, "\n")+"\n"), out)
+	s = signup{}
+	assert.NoError(t, m.Form(&s))
+	assert.Equal(t, "free", s.Plan)
+	assert.Equal(t, 1, s.Seats)
+	assert.False(t, s.Trial)
+
+	// answers, defaults and hidden fields
+	m = New(strings.NewReader(""), out,
+		WithInputPolicy(UseDefaults),
+		WithAnswers(Answers{"name": "bob", "password": "x", "start": "2025-01-31"}),
+	)
+	s = signup{}
+	assert.NoError(t, m.Form(&s, WithReview(false)))
+	assert.Equal(t, "free", s.Plan)
+	assert.Equal(t, 1, s.Seats)
+	assert.False(t, s.Trial)
+
+	// values beyond the field type
+	small := struct {
+		Level int8    `form:"level"`
+		Ratio float32 `form:"ratio"`
+	}{}
+	m = New(strings.NewReader("300\n100\n1e39\n0.5\n"), out)
+	assert.NoError(t, m.Form(&small, WithReview(false)))
+	assert.Equal(t, int8(100), small.Level)
+	assert.Equal(t, float32(0.5), small.Ratio)
+	assert.Contains(t, out.String(), "number out of range: 300")
+	assert.Contains(t, out.String(), "number out of range: 1e39")
+
+	// missing required input, invalid targets
+	m = New(strings.NewReader(""), out, WithInputPolicy(UseDefaults))
+	assert.ErrorAs(t, m.Form(&signup{}), &MissingInputError{})
+	assert.Error(t, m.Form(s))
+	assert.Error(t, m.Form(&struct {
+		Tags []string `form:"tags"`
+	}{}))
+}
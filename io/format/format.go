@@ -0,0 +1,216 @@
+package format
+
+import (
+	"fmt"
+	"github.com/rollicks-c/term/io/table"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Func[V any] func(value V) string
+
+var (
+	True  = "✓"
+	False = "✗"
+)
+
+func Col[T any, V any](value func(record T) V, f Func[V]) table.ColRenderer[T] {
+	return func(record T) (string, string) {
+		return "", f(value(record))
+	}
+}
+
+func Cell[T any, V any](value func(record T, header string) V, f Func[V]) table.CellRenderer[T] {
+	return func(record T, header string) (string, string) {
+		return "%s", f(value(record, header))
+	}
+}
+
+func OrEmpty[V comparable](placeholder string, f Func[V]) Func[V] {
+	return func(value V) string {
+		var zero V
+		if value == zero {
+			return placeholder
+		}
+		return f(value)
+	}
+}
+
+func Empty(value, placeholder string) string {
+	if strings.TrimSpace(value) == "" {
+		return placeholder
+	}
+	return value
+}
+
+func Bool(value bool) string {
+	if value {
+		return True
+	}
+	return False
+}
+
+func Thousands(value int64) string {
+	sign := ""
+	if value < 0 {
+		sign = "-"
+	}
+	digits := strconv.FormatUint(abs(value), 10)
+	return sign + group(digits)
+}
+
+func Decimal(places int) Func[float64] {
+	return func(value float64) string {
+		raw := strconv.FormatFloat(math.Abs(value), 'f', places, 64)
+		integer, fraction, _ := strings.Cut(raw, ".")
+		out := group(integer)
+		if fraction != "" {
+			out += "." + fraction
+		}
+		if value < 0 && strings.Trim(raw, "0.") != "" {
+			out = "-" + out
+		}
+		return out
+	}
+}
+
+func Percent(places int) Func[float64] {
+	return func(ratio float64) string {
+		return strconv.FormatFloat(ratio*100, 'f', places, 64) + "%"
+	}
+}
+
+func Bytes(value int64) string {
+	return byteSize(value, 1000, []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"})
+}
+
+func BytesIEC(value int64) string {
+	return byteSize(value, 1024, []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"})
+}
+
+func Duration(value time.Duration) string {
+
+	// short durations
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	if value < time.Minute {
+		return sign + value.Round(time.Second).String()
+	}
+
+	// hours and minutes
+	value = value.Round(time.Minute)
+	hours, minutes := int(value.Hours()), int(value.Minutes())%60
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%s%dm", sign, minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%s%dh", sign, hours)
+	}
+	return fmt.Sprintf("%s%dh%dm", sign, hours, minutes)
+}
+
+func Hours(places int) Func[time.Duration] {
+	return func(value time.Duration) string {
+		return strconv.FormatFloat(value.Hours(), 'f', places, 64)
+	}
+}
+
+func Clock(value time.Duration) string {
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	value = value.Round(time.Minute)
+	return fmt.Sprintf("%s%02d:%02d", sign, int(value.Hours()), int(value.Minutes())%60)
+}
+
+func Ago(value time.Time) string {
+	return Relative(time.Now())(value)
+}
+
+func Relative(now time.Time) Func[time.Time] {
+	return func(value time.Time) string {
+
+		// direction
+		diff := now.Sub(value)
+		pattern := "%s ago"
+		if diff < 0 {
+			pattern = "in %s"
+			diff = -diff
+		}
+
+		// largest fitting unit
+		units := []struct {
+			size time.Duration
+			name string
+		}{
+			{365 * 24 * time.Hour, "year"},
+			{30 * 24 * time.Hour, "month"},
+			{7 * 24 * time.Hour, "week"},
+			{24 * time.Hour, "day"},
+			{time.Hour, "hour"},
+			{time.Minute, "minute"},
+		}
+		for _, unit := range units {
+			count := int(diff / unit.size)
+			if count == 0 {
+				continue
+			}
+			return fmt.Sprintf(pattern, plural(count, unit.name))
+		}
+
+		return "just now"
+	}
+}
+
+func byteSize(value int64, base float64, units []string) string {
+
+	// no fraction for plain bytes
+	size := math.Abs(float64(value))
+	if size < base {
+		return fmt.Sprintf("%d %s", value, units[0])
+	}
+
+	// scale down
+	exp := 0
+	for size >= base && exp < len(units)-1 {
+		size /= base
+		exp++
+	}
+	if value < 0 {
+		size = -size
+	}
+
+	return fmt.Sprintf("%.1f %s", size, units[exp])
+}
+
+func group(digits string) string {
+	out := strings.Builder{}
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			out.WriteRune(',')
+		}
+		out.WriteRune(d)
+	}
+	return out.String()
+}
+
+func plural(count int, unit string) string {
+	if count == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+	return fmt.Sprintf("%d %ss", count, unit)
+}
+
+func abs(value int64) uint64 {
+	if value < 0 {
+		return uint64(-value)
+	}
+	return uint64(value)
+}
@@ -0,0 +1,98 @@
+package format
+
+import (
+	"github.com/rollicks-c/term/io/table"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNumbers(t *testing.T) {
+	assert.Equal(t, "0", Thousands(0))
+	assert.Equal(t, "999", Thousands(999))
+	assert.Equal(t, "1,234,567", Thousands(1234567))
+	assert.Equal(t, "-12,345", Thousands(-12345))
+
+	assert.Equal(t, "1,234.50", Decimal(2)(1234.5))
+	assert.Equal(t, "-1,000", Decimal(0)(-1000))
+	assert.Equal(t, "0.0", Decimal(1)(-0.01))
+
+	assert.Equal(t, "45.3%", Percent(1)(0.453))
+	assert.Equal(t, "100%", Percent(0)(1))
+
+	assert.Equal(t, "999 B", Bytes(999))
+	assert.Equal(t, "1.5 kB", Bytes(1500))
+	assert.Equal(t, "1.0 KiB", BytesIEC(1024))
+	assert.Equal(t, "-2.0 MiB", BytesIEC(-2*1024*1024))
+}
+
+func TestDurations(t *testing.T) {
+	assert.Equal(t, "7h45m", Duration(7*time.Hour+45*time.Minute))
+	assert.Equal(t, "8h", Duration(8*time.Hour+10*time.Second))
+	assert.Equal(t, "30m", Duration(30*time.Minute))
+	assert.Equal(t, "45s", Duration(45*time.Second))
+	assert.Equal(t, "-1h30m", Duration(-90*time.Minute))
+
+	assert.Equal(t, "7.75", Hours(2)(7*time.Hour+45*time.Minute))
+	assert.Equal(t, "07:45", Clock(7*time.Hour+45*time.Minute))
+	assert.Equal(t, "31:00", Clock(31*time.Hour))
+	assert.Equal(t, "-00:30", Clock(-30*time.Minute))
+}
+
+func TestRelative(t *testing.T) {
+	now := time.Date(2024, 10, 16, 12, 0, 0, 0, time.UTC)
+	rel := Relative(now)
+	assert.Equal(t, "just now", rel(now.Add(-20*time.Second)))
+	assert.Equal(t, "1 minute ago", rel(now.Add(-time.Minute)))
+	assert.Equal(t, "3 days ago", rel(now.Add(-3*24*time.Hour)))
+	assert.Equal(t, "in 2 hours", rel(now.Add(2*time.Hour+time.Minute)))
+	assert.Equal(t, "2 weeks ago", rel(now.Add(-15*24*time.Hour)))
+}
+
+func TestPlaceholders(t *testing.T) {
+	assert.Equal(t, "✓", Bool(true))
+	assert.Equal(t, "✗", Bool(false))
+	assert.Equal(t, "-", Empty("  ", "-"))
+	assert.Equal(t, "x", Empty("x", "-"))
+	assert.Equal(t, "n/a", OrEmpty("n/a", Duration)(0))
+	assert.Equal(t, "1h", OrEmpty("n/a", Duration)(time.Hour))
+}
+
+type task struct {
+	Name  string
+	Done  bool
+	Spent time.Duration
+}
+
+func TestRenderers(t *testing.T) {
+	cf := func(record task, header string) (string, string) {
+		if header != "name" {
+			return "%s", ""
+		}
+		return "%s", record.Name
+	}
+	builder := table.NewBuilder[task]().
+		AddHeaders("name", "done", "spent").
+		AddCellFormatter(cf).
+		AddColFormatter("done", Col(func(t task) bool { return t.Done }, Bool)).
+		AddColFormatter("spent", Col(func(t task) time.Duration { return t.Spent }, OrEmpty("-", Clock))).
+		AddRow(task{"write", true, 90 * time.Minute}, task{"review", false, 0})
+
+	act := builder.Build()
+	exp := `
+name  	done	spent
+------	----	-----
+write 	✓   	01:30
+review	✗   	-    
+`
+	act = strings.Trim(act, "\n")
+	exp = strings.Trim(exp, "\n")
+	assert.Equal(t, exp, act)
+
+	builder = table.NewBuilder[task]().
+		AddHeaders("spent").
+		AddCellFormatter(Cell(func(t task, header string) time.Duration { return t.Spent }, Duration)).
+		AddRow(task{Spent: time.Minute})
+	assert.Contains(t, builder.Build(), "1m")
+}
@@ -1,9 +1,11 @@
 package io
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
 	"github.com/manifoldco/promptui"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -16,29 +18,82 @@ func WithDefault(defaultValue string) PromptOption {
 	}
 }
 
+func WithValidation(validate func(value string) error) PromptOption {
+	return func(p *promptui.Prompt) {
+		p.Validate = validate
+	}
+}
+
+func WithRequired() PromptOption {
+	return WithValidation(func(value string) error {
+		if strings.TrimSpace(value) == "" {
+			return fmt.Errorf("value required")
+		}
+		return nil
+	})
+}
+
 func (m Module) Confirm(prompt string, v ...interface{}) bool {
+	confirmed, err := m.ConfirmE(prompt, v...)
 
-	msg := fmt.Sprintf("%s (y/n): ", prompt)
-	m.WarnF(msg, v...)
-	var response string
-	if _, err := fmt.Fscan(m.in, &response); err != nil {
+	// failures read as "no", ConfirmE tells them apart
+	var missing MissingInputError
+	if errors.As(err, &missing) {
+		m.FailF("%v\n", err)
+		return false
+	}
+	if err != nil {
 		m.FailF("unable to read confirmation response: %v", err)
+		return false
 	}
-	response = strings.TrimSpace(strings.ToLower(response))
-	return response == "y"
+	return confirmed
+}
 
+func (m Module) ConfirmE(prompt string, v ...interface{}) (bool, error) {
+
+	// auto-confirm
+	if m.assumeYes {
+		return true, nil
+	}
+
+	// answered up front
+	q := Question{Label: fmt.Sprintf(prompt, v...), Default: "n"}
+	answer, ok, err := m.resolve(q)
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		confirmed := answer == "y" || answer == "yes" || answer == "true"
+		if err := m.record(q, yesNo(confirmed)); err != nil {
+			return false, err
+		}
+		return confirmed, nil
+	}
+
+	// ask
+	confirmed, err := m.engine().Confirm(Warn(q.Label))
+	if err != nil {
+		answer, err := m.exhausted(q, err)
+		if err != nil {
+			return false, err
+		}
+		confirmed = answer == "y"
+	}
+	if err := m.record(q, yesNo(confirmed)); err != nil {
+		return false, err
+	}
+	return confirmed, nil
 }
 
 func (m Module) ChooseManual(prompt string, min, max int, v ...interface{}) (int, bool) {
 
-	// prompt
-	msg := fmt.Sprintf("%s: ", prompt)
-	m.WarnF(msg, v...)
-
-	// get response
-	buf := bufio.NewReader(m.in)
-	response, err := buf.ReadString('\n')
-	response = strings.ReplaceAll(response, "\n", "")
+	// prompt and get response
+	label := fmt.Sprintf(prompt, v...)
+	response, err := m.ask(Question{Label: Warn(label)})
+	if err != nil {
+		return -1, false
+	}
 
 	// no response
 	if strings.TrimSpace(response) == "" {
@@ -57,43 +112,30 @@ func (m Module) ChooseManual(prompt string, min, max int, v ...interface{}) (int
 	}
 
 	// valid response
-	response = strings.TrimSpace(strings.ToLower(response))
 	return choice, true
 
 }
 
 func (m Module) Choose(prompt string, list map[string]any) (any, error) {
 
-	candidates := make([]string, 0, len(list))
-	for k := range list {
-		candidates = append(candidates, k)
-	}
+	candidates := slices.Sorted(maps.Keys(list))
 
-	selector := promptui.Select{
-		Label:     prompt,
-		Items:     candidates,
-		CursorPos: 0,
-		Templates: &promptui.SelectTemplates{Details: ""},
-	}
-	_, result, err := selector.Run()
+	index, err := m.selectItem(Selection{
+		Label: prompt,
+		Items: candidates,
+	})
 	if err != nil {
 		return nil, err
 	}
-	selectedTask, ok := list[result]
+	selectedTask, ok := list[candidates[index]]
 	if !ok {
-		return nil, fmt.Errorf("invalid task: %s", result)
+		return nil, fmt.Errorf("invalid task: %s", candidates[index])
 	}
 	return selectedTask, nil
 }
 
 func (m Module) PromptString(text string, options ...PromptOption) (string, error) {
-	prompt := promptui.Prompt{
-		Label: text,
-	}
-	for _, opt := range options {
-		opt(&prompt)
-	}
-	response, err := prompt.Run()
+	response, err := m.ask(Question{Label: text}, options...)
 	if err != nil {
 		return "", err
 	}
@@ -103,13 +145,7 @@ func (m Module) PromptString(text string, options ...PromptOption) (string, erro
 }
 
 func (m Module) PromptInt(text string, options ...PromptOption) (int, error) {
-	prompt := promptui.Prompt{
-		Label: text,
-	}
-	for _, opt := range options {
-		opt(&prompt)
-	}
-	response, err := prompt.Run()
+	response, err := m.ask(Question{Label: text}, options...)
 	if err != nil {
 		return 0, err
 	}
@@ -121,12 +157,21 @@ func (m Module) PromptInt(text string, options ...PromptOption) (int, error) {
 	return value, nil
 }
 
-func (m Module) PromptPassword(text string) (string, error) {
-	prompt := promptui.Prompt{
-		Label: text,
-		Mask:  '*',
+func (m Module) PromptFloat(text string, options ...PromptOption) (float64, error) {
+	response, err := m.ask(Question{Label: text}, options...)
+	if err != nil {
+		return 0, err
+	}
+
+	value, err := strconv.ParseFloat(response, 64)
+	if err != nil {
+		return 0, err
 	}
-	response, err := prompt.Run()
+	return value, nil
+}
+
+func (m Module) PromptPassword(text string, options ...PromptOption) (string, error) {
+	response, err := m.ask(Question{Label: text, Secret: true}, options...)
 	if err != nil {
 		return "", err
 	}
@@ -135,3 +180,98 @@ func (m Module) PromptPassword(text string) (string, error) {
 	return response, nil
 
 }
+
+func (m Module) Ask(q Question, options ...PromptOption) (string, error) {
+	return m.ask(q, options...)
+}
+
+func (m Module) ask(q Question, options ...PromptOption) (string, error) {
+	q.apply(options)
+	answer, ok, err := m.resolve(q)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		answer, err = m.engine().Ask(q)
+		if err != nil {
+			if answer, err = m.exhausted(q, err); err != nil {
+				return "", err
+			}
+		}
+	}
+	if err := m.record(q, answer); err != nil {
+		return "", err
+	}
+	return answer, nil
+}
+
+func (q *Question) apply(options []PromptOption) {
+
+	// options still target promptui, carry their settings over
+	prompt := promptui.Prompt{
+		Label:    q.Label,
+		Default:  q.Default,
+		Validate: q.Validate,
+	}
+	if q.Secret {
+		prompt.Mask = '*'
+	}
+	for _, opt := range options {
+		opt(&prompt)
+	}
+	q.Label = fmt.Sprint(prompt.Label)
+	q.Default = prompt.Default
+	q.Validate = prompt.Validate
+	q.Secret = prompt.Mask != 0
+}
+
+func (m Module) selectItem(s Selection) (int, error) {
+
+	// answered by label or number, the default names the cursor's label
+	q := Question{
+		Key:     s.Key,
+		Label:   s.Label,
+		Default: fmt.Sprintf("%d", s.Cursor+1),
+	}
+	if s.Cursor < len(s.Items) {
+		q.Default = s.Items[s.Cursor]
+	}
+	answer, ok, err := m.resolve(q)
+	if err != nil {
+		return -1, err
+	}
+	if !ok {
+		index, err := m.engine().Select(s)
+		if err == nil {
+			if err := m.record(q, s.Items[index]); err != nil {
+				return -1, err
+			}
+			return index, nil
+		}
+		if answer, err = m.exhausted(q, err); err != nil {
+			return -1, err
+		}
+	}
+	index := s.match(answer)
+	if index < 0 || s.disabled(index) {
+		return -1, fmt.Errorf("invalid answer for %s: %s", q.key(), answer)
+	}
+	if err := m.record(q, s.Items[index]); err != nil {
+		return -1, err
+	}
+	return index, nil
+}
+
+func yesNo(state bool) string {
+	if state {
+		return "y"
+	}
+	return "n"
+}
+
+func (m Module) engine() PromptEngine {
+	if m.prompts == nil {
+		return newPromptEngine(m.in, m.out)
+	}
+	return m.prompts
+}
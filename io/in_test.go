@@ -0,0 +1,85 @@
+package io
+
+import (
+	"bytes"
+	"github.com/manifoldco/promptui"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestLinePrompts(t *testing.T) {
+	in := strings.NewReader("\n\nAlice\nabc\n42\ny\n2\n")
+	out := &bytes.Buffer{}
+	m := New(in, out)
+
+	// default on empty input
+	name, err := m.PromptString("name", WithDefault("Bob"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Bob", name)
+
+	// required re-prompts
+	name, err = m.PromptString("name", WithRequired())
+	assert.NoError(t, err)
+	assert.Equal(t, "Alice", name)
+	assert.Contains(t, out.String(), "value required")
+
+	// invalid number
+	_, err = m.PromptInt("count")
+	assert.Error(t, err)
+	count, err := m.PromptInt("count")
+	assert.NoError(t, err)
+	assert.Equal(t, 42, count)
+
+	// confirm and select
+	assert.True(t, m.Confirm("continue?"))
+	index, err := m.engine().Select(Selection{Label: "pick", Items: []string{"a", "b"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, index)
+
+	// input exhausted, the default answers
+	more, err := m.PromptString("more", WithDefault("none"))
+	assert.NoError(t, err)
+	assert.Equal(t, "none", more)
+}
+
+func TestPromptOptions(t *testing.T) {
+	out := &bytes.Buffer{}
+	m := New(strings.NewReader("\n"), out)
+
+	// promptui options written against the old type still apply
+	masked := func(p *promptui.Prompt) {
+		p.Label = "token"
+		p.Default = "secret"
+		p.Mask = '*'
+	}
+	token, err := m.PromptString("key", masked)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", token)
+	assert.Equal(t, "token: ", out.String())
+
+	// the default is shown once
+	out.Reset()
+	m = New(strings.NewReader("\n"), out)
+	_, err = m.PromptString("name", WithDefault("Bob"))
+	assert.NoError(t, err)
+	assert.Equal(t, "name [Bob]: ", out.String())
+}
+
+func TestChooseMap(t *testing.T) {
+	list := map[string]any{"c": 3, "a": 1, "b": 2}
+
+	// numbers refer to sorted keys, not map order
+	for range 10 {
+		m := New(strings.NewReader("1\n"), &bytes.Buffer{})
+		choice, err := m.Choose("pick", list)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, choice)
+	}
+
+	// defaults pick the first sorted key
+	m := New(strings.NewReader(""), &bytes.Buffer{}, WithInputPolicy(UseDefaults))
+	choice, err := m.Choose("pick", list)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, choice)
+}
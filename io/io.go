@@ -6,6 +6,13 @@ type Module struct {
 	in        io.Reader
 	out       io.Writer
 	debugMode bool
+	paging    bool
+	prompts   PromptEngine
+	policy    InputPolicy
+	policySet bool
+	answers   []AnswerSource
+	assumeYes bool
+	recorder  *Recorder
 }
 
 type Option func(*Module)
@@ -15,9 +22,14 @@ func New(in io.Reader, out io.Writer, options ...Option) *Module {
 		in:        in,
 		out:       out,
 		debugMode: false,
+		paging:    true,
+		policy:    AskAlways,
 	}
 	for _, opt := range options {
 		opt(m)
 	}
+	if m.prompts == nil {
+		m.prompts = newPromptEngine(m.in, m.out)
+	}
 	return m
 }
@@ -0,0 +1,22 @@
+package io
+
+import (
+	"fmt"
+	"github.com/rollicks-c/term/internal/tty"
+	"github.com/rollicks-c/term/io/layout"
+)
+
+func (m Module) SideBySide(blocks []string, options ...layout.Option) {
+	if width, _, ok := tty.Size(m.out); ok {
+		options = append([]layout.Option{layout.WithMaxWidth(width)}, options...)
+	}
+	if _, err := fmt.Fprintln(m.out, layout.Horizontal(blocks, options...)); err != nil {
+		fmt.Print(err)
+	}
+}
+
+func (m Module) Stack(blocks []string, options ...layout.Option) {
+	if _, err := fmt.Fprintln(m.out, layout.Vertical(blocks, options...)); err != nil {
+		fmt.Print(err)
+	}
+}
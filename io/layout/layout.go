@@ -0,0 +1,166 @@
+package layout
+
+import (
+	"github.com/rollicks-c/term/internal/text"
+	"strings"
+)
+
+const tabWidth = 8
+
+type Align int
+
+const (
+	Start Align = iota
+	Center
+	End
+)
+
+type Option func(config *Config)
+
+type Config struct {
+	Gap      int
+	Align    Align
+	Widths   []int
+	MaxWidth int
+}
+
+type block struct {
+	lines []string
+	width int
+}
+
+func WithGap(size int) Option {
+	return func(config *Config) {
+		config.Gap = size
+	}
+}
+
+func WithAlign(align Align) Option {
+	return func(config *Config) {
+		config.Align = align
+	}
+}
+
+func WithWidths(widths ...int) Option {
+	return func(config *Config) {
+		config.Widths = widths
+	}
+}
+
+func WithMaxWidth(width int) Option {
+	return func(config *Config) {
+		config.MaxWidth = width
+	}
+}
+
+func Horizontal(blocks []string, options ...Option) string {
+
+	// measure blocks
+	config := newConfig(1, options...)
+	measured := measure(blocks, config)
+	total := config.Gap * max(len(measured)-1, 0)
+	height := 0
+	for _, b := range measured {
+		total += b.width
+		height = max(height, len(b.lines))
+	}
+
+	// stack blocks that do not fit side by side
+	if config.MaxWidth > 0 && total > config.MaxWidth {
+		return Vertical(blocks, append(options, WithGap(1), WithAlign(Start))...)
+	}
+
+	// place blocks line by line
+	gap := strings.Repeat(" ", config.Gap)
+	out := make([]string, height)
+	for row := range out {
+		cells := make([]string, len(measured))
+		for i, b := range measured {
+			cells[i] = text.Pad(b.line(row-offset(height-len(b.lines), config.Align)), b.width)
+		}
+		out[row] = strings.TrimRight(strings.Join(cells, gap), " ")
+	}
+
+	return strings.Join(out, "\n")
+}
+
+func Vertical(blocks []string, options ...Option) string {
+
+	// measure blocks
+	config := newConfig(0, options...)
+	measured := measure(blocks, config)
+	width := 0
+	for _, b := range measured {
+		width = max(width, b.width)
+	}
+	if config.MaxWidth > 0 {
+		width = min(width, config.MaxWidth)
+	}
+
+	// stack blocks, aligned within the widest one
+	out := make([]string, 0)
+	for i, b := range measured {
+		if i > 0 {
+			out = append(out, make([]string, config.Gap)...)
+		}
+		indent := strings.Repeat(" ", offset(width-min(b.width, width), config.Align))
+		for _, line := range b.lines {
+			out = append(out, strings.TrimRight(indent+text.Truncate(line, width), " "))
+		}
+	}
+
+	return strings.Join(out, "\n")
+}
+
+func newConfig(gap int, options ...Option) *Config {
+	config := &Config{
+		Gap:   gap,
+		Align: Start,
+	}
+	for _, opt := range options {
+		opt(config)
+	}
+	return config
+}
+
+func measure(blocks []string, config *Config) []block {
+	measured := make([]block, len(blocks))
+	for i, content := range blocks {
+
+		// tabs are expanded so widths match the terminal
+		lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+		width := 0
+		for j, line := range lines {
+			lines[j] = text.ExpandTabs(line, tabWidth)
+			width = max(width, text.Width(lines[j]))
+		}
+
+		// fixed widths truncate wider content
+		if i < len(config.Widths) && config.Widths[i] > 0 {
+			width = config.Widths[i]
+			for j, line := range lines {
+				lines[j] = text.Truncate(line, width)
+			}
+		}
+
+		measured[i] = block{lines: lines, width: width}
+	}
+	return measured
+}
+
+func (b block) line(index int) string {
+	if index < 0 || index >= len(b.lines) {
+		return ""
+	}
+	return b.lines[index]
+}
+
+func offset(space int, align Align) int {
+	switch align {
+	case Center:
+		return space / 2
+	case End:
+		return space
+	}
+	return 0
+}
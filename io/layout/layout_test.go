@@ -0,0 +1,42 @@
+package layout
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestHorizontal(t *testing.T) {
+	left := "name\tqty\nab\t1\n"
+	right := "\033[1;32mok\033[0m"
+
+	exp := strings.Join([]string{
+		"name    qty  \033[1;32mok\033[0m",
+		"ab      1",
+	}, "\n")
+	assert.Equal(t, exp, Horizontal([]string{left, right}, WithGap(2)))
+
+	// aligned to the bottom with fixed widths
+	exp = strings.Join([]string{
+		"name  |",
+		"ab    | \033[1;32mok\033[0m",
+	}, "\n")
+	assert.Equal(t, exp, Horizontal([]string{left, "|\n|", right}, WithWidths(5), WithAlign(End)))
+
+	// too wide blocks are stacked
+	exp = strings.Join([]string{
+		"aaaa",
+		"",
+		"bbbb",
+	}, "\n")
+	assert.Equal(t, exp, Horizontal([]string{"aaaa", "bbbb"}, WithMaxWidth(6)))
+}
+
+func TestVertical(t *testing.T) {
+	exp := strings.Join([]string{
+		"title",
+		"",
+		" ab",
+	}, "\n")
+	assert.Equal(t, exp, Vertical([]string{"title", "ab"}, WithGap(1), WithAlign(Center)))
+}
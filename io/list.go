@@ -0,0 +1,143 @@
+package io
+
+import (
+	"fmt"
+	"github.com/rollicks-c/term/internal/text"
+	"github.com/rollicks-c/term/internal/tty"
+	"strings"
+)
+
+type ListOption func(config *listConfig)
+
+type listConfig struct {
+	rowMajor bool
+	gap      int
+	width    int
+	color    func(item string) ColPrint
+}
+
+func WithRowMajor() ListOption {
+	return func(config *listConfig) {
+		config.rowMajor = true
+	}
+}
+
+func WithListGap(size int) ListOption {
+	return func(config *listConfig) {
+		config.gap = size
+	}
+}
+
+func WithListWidth(width int) ListOption {
+	return func(config *listConfig) {
+		config.width = width
+	}
+}
+
+func WithItemColor(color func(item string) ColPrint) ListOption {
+	return func(config *listConfig) {
+		config.color = color
+	}
+}
+
+func (m Module) List(items []string, options ...ListOption) {
+	if len(items) == 0 {
+		return
+	}
+	if _, err := fmt.Fprintln(m.out, m.SList(items, options...)); err != nil {
+		fmt.Print(err)
+	}
+}
+
+func (m Module) SList(items []string, options ...ListOption) string {
+
+	// init config, pipes get one item per line
+	config := listConfig{
+		gap: 2,
+	}
+	if width, _, ok := tty.Size(m.out); ok {
+		config.width = width
+	}
+	for _, opt := range options {
+		opt(&config)
+	}
+
+	// style items
+	styled := make([]string, len(items))
+	for i, item := range items {
+		styled[i] = item
+		if config.color != nil {
+			styled[i] = config.color(item)(item)
+		}
+	}
+
+	return columnize(styled, config)
+}
+
+func columnize(items []string, config listConfig) string {
+
+	// no width to fill
+	if config.width <= 0 || len(items) == 0 {
+		return strings.Join(items, "\n")
+	}
+
+	// measure once, uneven widths make fitting counts non-monotonic
+	itemWidths := make([]int, len(items))
+	for i, item := range items {
+		itemWidths[i] = text.Width(item)
+	}
+
+	// find the most columns that fit
+	widths, rows := listWidths(itemWidths, 1, config.rowMajor)
+	for cols := len(items); cols > 1; cols-- {
+		candidate, r := listWidths(itemWidths, cols, config.rowMajor)
+		total := config.gap * (len(candidate) - 1)
+		for _, w := range candidate {
+			total += w
+		}
+		if total <= config.width {
+			widths, rows = candidate, r
+			break
+		}
+	}
+
+	// render grid
+	gap := strings.Repeat(" ", config.gap)
+	lines := make([]string, rows)
+	for row := range lines {
+		cells := make([]string, 0, len(widths))
+		for col, width := range widths {
+			index := listIndex(row, col, rows, len(widths), config.rowMajor)
+			if index >= len(items) {
+				break
+			}
+			cells = append(cells, text.Pad(items[index], width))
+		}
+		lines[row] = strings.TrimRight(strings.Join(cells, gap), " ")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func listWidths(itemWidths []int, cols int, rowMajor bool) ([]int, int) {
+	rows := (len(itemWidths) + cols - 1) / cols
+	if !rowMajor {
+		cols = (len(itemWidths) + rows - 1) / rows
+	}
+	widths := make([]int, cols)
+	for i, width := range itemWidths {
+		col := i % cols
+		if !rowMajor {
+			col = i / rows
+		}
+		widths[col] = max(widths[col], width)
+	}
+	return widths, rows
+}
+
+func listIndex(row, col, rows, cols int, rowMajor bool) int {
+	if rowMajor {
+		return row*cols + col
+	}
+	return col*rows + row
+}
@@ -0,0 +1,64 @@
+package io
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	m := New(&bytes.Buffer{}, &bytes.Buffer{})
+	items := []string{"alpha", "beta", "gamma", "delta", "epsilon"}
+
+	// not a terminal
+	assert.Equal(t, strings.Join(items, "\n"), m.SList(items))
+
+	// column-major
+	exp := strings.Join([]string{
+		"alpha  gamma  epsilon",
+		"beta   delta",
+	}, "\n")
+	assert.Equal(t, exp, m.SList(items, WithListWidth(24)))
+
+	// row-major
+	exp = strings.Join([]string{
+		"alpha  beta     gamma",
+		"delta  epsilon",
+	}, "\n")
+	assert.Equal(t, exp, m.SList(items, WithListWidth(24), WithRowMajor()))
+
+	// colors do not count
+	exp = strings.Join([]string{
+		Success("alpha") + "  " + Success("gamma") + "  " + Success("epsilon"),
+		Success("beta") + "   " + Success("delta"),
+	}, "\n")
+	color := func(item string) ColPrint { return Success }
+	assert.Equal(t, exp, m.SList(items, WithListWidth(24), WithItemColor(color)))
+
+	// long lists fill the width
+	letters := strings.Split("abcdefghijklmnopqrstuvwxyz", "")
+	lines := strings.Split(m.SList(letters, WithListWidth(11)), "\n")
+	assert.Len(t, lines, 7)
+	assert.Equal(t, "a  h  o  v", lines[0])
+
+	// fewer columns may overflow where more fit
+	uneven := func(widths ...int) []string {
+		items := make([]string, len(widths))
+		for i, width := range widths {
+			items[i] = strings.Repeat("x", width)
+		}
+		return items
+	}
+	exp = strings.Join([]string{
+		"x     xxxxxxxxx     x",
+		"xxx   xxxxxxxxx",
+		"xxxx  xxxxxxxxxxxx",
+	}, "\n")
+	assert.Equal(t, exp, m.SList(uneven(1, 3, 4, 9, 9, 12, 1), WithListWidth(21)))
+	exp = strings.Join([]string{
+		"xxxxxxxxx  xxxxxxxxxxxx  x  xxxx",
+		"x          xxxxxxxxxx    x  x",
+	}, "\n")
+	assert.Equal(t, exp, m.SList(uneven(9, 12, 1, 4, 1, 10, 1, 1), WithListWidth(33), WithRowMajor()))
+}
@@ -0,0 +1,308 @@
+package io
+
+import (
+	"fmt"
+	"github.com/rollicks-c/term/internal/text"
+	"github.com/rollicks-c/term/internal/tty"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+)
+
+const defaultPager = "less -R"
+
+type pager struct {
+	lines     []string
+	plain     []string
+	offset    int
+	height    int
+	query     string
+	input     string
+	searching bool
+	status    string
+}
+
+func WithPaging(state bool) Option {
+	return func(m *Module) {
+		m.paging = state
+	}
+}
+
+func (m Module) Page(content string) {
+
+	// short content or no terminal
+	if !m.shouldPage(content) {
+		if _, err := fmt.Fprint(m.out, content); err != nil {
+			fmt.Print(err)
+		}
+		return
+	}
+
+	// external pager
+	if cmd, err := m.startExternalPager(content); err == nil {
+		if err := waitPager(cmd); err != nil {
+			m.FailF("pager failed: %v\n", err)
+		}
+		return
+	}
+
+	// built-in when no external pager can be started
+	if err := m.runBuiltinPager(content); err != nil {
+		if _, err := fmt.Fprint(m.out, content); err != nil {
+			fmt.Print(err)
+		}
+	}
+}
+
+func (m Module) PageF(format string, v ...interface{}) {
+	m.Page(fmt.Sprintf(format, v...))
+}
+
+func (m Module) shouldPage(content string) bool {
+	if !m.paging {
+		return false
+	}
+	_, height, ok := tty.Size(m.out)
+	if !ok {
+		return false
+	}
+	lines := strings.Count(strings.TrimSuffix(content, "\n"), "\n") + 1
+	return lines >= height
+}
+
+func (m Module) startExternalPager(content string) (*exec.Cmd, error) {
+
+	// resolve command
+	command := strings.TrimSpace(os.Getenv("PAGER"))
+	if command == "" {
+		command = defaultPager
+	}
+	fields := strings.Fields(command)
+	path, err := exec.LookPath(fields[0])
+	if err != nil {
+		return nil, err
+	}
+
+	// start
+	cmd := exec.Command(path, fields[1:]...)
+	cmd.Stdin = strings.NewReader(content)
+	cmd.Stdout = m.out
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+func waitPager(cmd *exec.Cmd) error {
+
+	// Ctrl-C belongs to the pager while it runs, catching rather than
+	// ignoring the signal keeps handlers the application installed
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+	return cmd.Wait()
+}
+
+func (m Module) runBuiltinPager(content string) error {
+
+	// enter full screen
+	term, err := tty.Open(m.in, m.out)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = term.Close()
+	}()
+
+	// interact
+	p := newPager(content)
+	for {
+		width, height := term.Size()
+		p.height = height - 1
+		p.scroll(0)
+		if err := term.Draw(p.view(width)); err != nil {
+			return err
+		}
+		key, err := term.ReadKey()
+		if err != nil {
+			return err
+		}
+		if quit := p.handle(key); quit {
+			return nil
+		}
+	}
+}
+
+func newPager(content string) *pager {
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	plain := make([]string, len(lines))
+	for i, line := range lines {
+		plain[i] = text.Strip(line)
+	}
+	return &pager{
+		lines:  lines,
+		plain:  plain,
+		height: 1,
+	}
+}
+
+func (p *pager) handle(key tty.Key) bool {
+
+	// search input
+	if p.searching {
+		switch key.Code {
+		case tty.KeyEnter:
+			p.searching = false
+			p.query = p.input
+			p.find(p.offset, 1)
+		case tty.KeyEsc, tty.KeyCtrlC:
+			p.searching = false
+		case tty.KeyBackspace:
+			if len(p.input) > 0 {
+				runes := []rune(p.input)
+				p.input = string(runes[:len(runes)-1])
+			}
+		case tty.KeyRune:
+			p.input += string(key.Rune)
+		}
+		return false
+	}
+
+	// navigation
+	p.status = ""
+	switch key.Code {
+	case tty.KeyCtrlC, tty.KeyCtrlD, tty.KeyEsc:
+		return true
+	case tty.KeyDown, tty.KeyEnter:
+		p.scroll(1)
+	case tty.KeyUp:
+		p.scroll(-1)
+	case tty.KeyPgDn:
+		p.scroll(p.height)
+	case tty.KeyPgUp:
+		p.scroll(-p.height)
+	case tty.KeyHome:
+		p.offset = 0
+	case tty.KeyEnd:
+		p.scroll(len(p.lines))
+	case tty.KeyRune:
+		switch key.Rune {
+		case 'q', 'Q':
+			return true
+		case 'j':
+			p.scroll(1)
+		case 'k':
+			p.scroll(-1)
+		case ' ', 'f':
+			p.scroll(p.height)
+		case 'b':
+			p.scroll(-p.height)
+		case 'd':
+			p.scroll(p.height / 2)
+		case 'u':
+			p.scroll(-p.height / 2)
+		case 'g':
+			p.offset = 0
+		case 'G':
+			p.scroll(len(p.lines))
+		case '/':
+			p.searching = true
+			p.input = ""
+		case 'n':
+			p.find(p.offset+1, 1)
+		case 'N':
+			p.find(p.offset-1, -1)
+		}
+	}
+
+	return false
+}
+
+func (p *pager) scroll(delta int) {
+	p.offset += delta
+	maxOffset := len(p.lines) - p.height
+	if p.offset > maxOffset {
+		p.offset = maxOffset
+	}
+	if p.offset < 0 {
+		p.offset = 0
+	}
+}
+
+func (p *pager) find(from, direction int) {
+
+	// nothing to search
+	if p.query == "" {
+		return
+	}
+
+	// walk lines in direction
+	for i := from; i >= 0 && i < len(p.lines); i += direction {
+		if p.matches(p.plain[i]) {
+			p.offset = i
+			p.scroll(0)
+			return
+		}
+	}
+	p.status = fmt.Sprintf("pattern not found: %s", p.query)
+}
+
+func (p *pager) matches(line string) bool {
+	return strings.Contains(strings.ToLower(line), strings.ToLower(p.query))
+}
+
+func (p *pager) view(width int) []string {
+
+	// visible lines
+	view := make([]string, 0, p.height+1)
+	for i := p.offset; i < p.offset+p.height && i < len(p.lines); i++ {
+		line := p.lines[i]
+		if p.query != "" && p.matches(p.plain[i]) {
+			line = p.highlight(p.plain[i])
+		}
+		view = append(view, text.Truncate(line, width))
+	}
+	for len(view) < p.height {
+		view = append(view, "~")
+	}
+
+	// status line
+	var status string
+	switch {
+	case p.searching:
+		status = "/" + p.input
+	case p.status != "":
+		status = p.status
+	case p.offset+p.height >= len(p.lines):
+		status = "\033[7m(END)\033[0m"
+	default:
+		last := p.offset + p.height
+		status = fmt.Sprintf("\033[7mlines %d-%d/%d\033[0m", p.offset+1, last, len(p.lines))
+	}
+	view = append(view, text.Truncate(status, width))
+
+	return view
+}
+
+func (p *pager) highlight(line string) string {
+	lower := strings.ToLower(line)
+	query := strings.ToLower(p.query)
+	if len(lower) != len(line) {
+		lower, query = line, p.query
+	}
+	out := strings.Builder{}
+	for {
+		i := strings.Index(lower, query)
+		if i < 0 {
+			out.WriteString(line)
+			break
+		}
+		out.WriteString(line[:i])
+		out.WriteString("\033[7m" + line[i:i+len(query)] + "\033[27m")
+		line = line[i+len(query):]
+		lower = lower[i+len(query):]
+	}
+	return out.String()
+}
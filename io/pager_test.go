@@ -0,0 +1,103 @@
+package io
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/rollicks-c/term/internal/tty"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func createPager(lineCount, height int) *pager {
+	lines := make([]string, lineCount)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i)
+	}
+	p := newPager(strings.Join(lines, "\n"))
+	p.height = height
+	return p
+}
+
+func TestPagerScroll(t *testing.T) {
+
+	p := createPager(10, 4)
+
+	p.handle(tty.Key{Code: tty.KeyDown})
+	assert.Equal(t, 1, p.offset)
+
+	p.handle(tty.Key{Code: tty.KeyRune, Rune: ' '})
+	assert.Equal(t, 5, p.offset)
+
+	p.handle(tty.Key{Code: tty.KeyRune, Rune: 'G'})
+	assert.Equal(t, 6, p.offset)
+
+	p.handle(tty.Key{Code: tty.KeyPgDn})
+	assert.Equal(t, 6, p.offset)
+
+	p.handle(tty.Key{Code: tty.KeyRune, Rune: 'g'})
+	assert.Equal(t, 0, p.offset)
+
+	p.handle(tty.Key{Code: tty.KeyUp})
+	assert.Equal(t, 0, p.offset)
+
+	assert.True(t, p.handle(tty.Key{Code: tty.KeyRune, Rune: 'q'}))
+}
+
+func TestPagerSearch(t *testing.T) {
+
+	p := createPager(20, 4)
+	for _, key := range tty.Decode([]byte("/LINE 1\r")) {
+		assert.False(t, p.handle(key))
+	}
+	assert.Equal(t, "LINE 1", p.query)
+	assert.Equal(t, 1, p.offset)
+
+	p.handle(tty.Key{Code: tty.KeyRune, Rune: 'n'})
+	assert.Equal(t, 10, p.offset)
+
+	p.handle(tty.Key{Code: tty.KeyRune, Rune: 'N'})
+	assert.Equal(t, 1, p.offset)
+
+	view := p.view(80)
+	assert.Len(t, view, 5)
+	assert.Equal(t, "\033[7mline 1\033[27m", view[0])
+	assert.Equal(t, "line 2", view[1])
+
+	for _, key := range tty.Decode([]byte("/none\r")) {
+		p.handle(key)
+	}
+	assert.Equal(t, 1, p.offset)
+	assert.Equal(t, "pattern not found: none", p.view(80)[4])
+}
+
+func TestExternalPager(t *testing.T) {
+	out := &bytes.Buffer{}
+	m := New(strings.NewReader(""), out)
+
+	// started pagers own the outcome, failing exits included
+	t.Setenv("PAGER", "false")
+	cmd, err := m.startExternalPager("text")
+	assert.NoError(t, err)
+	assert.Error(t, cmd.Wait())
+
+	// interrupts reach the pager, not us
+	t.Setenv("PAGER", "sleep 0.5")
+	cmd, err = m.startExternalPager("text")
+	assert.NoError(t, err)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		self, err := os.FindProcess(os.Getpid())
+		if err == nil {
+			_ = self.Signal(os.Interrupt)
+		}
+	}()
+	assert.NoError(t, waitPager(cmd))
+
+	// missing pagers fall back
+	t.Setenv("PAGER", "no-such-pager-term-test")
+	_, err = m.startExternalPager("text")
+	assert.Error(t, err)
+}
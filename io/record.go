@@ -0,0 +1,177 @@
+package io
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/rollicks-c/term/internal/text"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type Recorder struct {
+	lock    sync.Mutex
+	keys    []string
+	labels  map[string]string
+	answers Answers
+}
+
+type Replay struct {
+	lock    sync.Mutex
+	answers Answers
+	used    map[string]bool
+	missing []string
+}
+
+func WithRecorder(recorder *Recorder) Option {
+	return func(m *Module) {
+		m.recorder = recorder
+	}
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{
+		labels:  make(map[string]string),
+		answers: make(Answers),
+	}
+}
+
+func (r *Recorder) Record(key, label, answer string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// one prompt per key, a replay could not tell them apart
+	other, ok := r.labels[key]
+	if ok && other != label {
+		return fmt.Errorf("duplicate prompt key %s for %q and %q", key, other, label)
+	}
+	if !ok {
+		r.keys = append(r.keys, key)
+	}
+	r.labels[key] = label
+	r.answers[key] = answer
+	return nil
+}
+
+func (r *Recorder) Answers() Answers {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	answers := make(Answers, len(r.answers))
+	for key, answer := range r.answers {
+		answers[key] = answer
+	}
+	return answers
+}
+
+func (r *Recorder) Save(path string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// json by extension, yaml in prompt order otherwise
+	var raw []byte
+	var err error
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		raw, err = json.MarshalIndent(r.answers, "", "  ")
+	} else {
+		doc := &yaml.Node{Kind: yaml.MappingNode}
+		for _, key := range r.keys {
+			doc.Content = append(doc.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: key},
+				&yaml.Node{Kind: yaml.ScalarNode, Value: r.answers[key], Style: yaml.DoubleQuotedStyle},
+			)
+		}
+		raw, err = yaml.Marshal(doc)
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, raw, 0o600)
+}
+
+func NewReplay(answers Answers) *Replay {
+	return &Replay{
+		answers: answers,
+		used:    make(map[string]bool),
+	}
+}
+
+func LoadReplay(path string) (*Replay, error) {
+	answers, err := LoadAnswers(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewReplay(answers), nil
+}
+
+func (r *Replay) Answer(key string) (string, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	value, ok := r.answers[key]
+	if !ok {
+		return "", false
+	}
+	r.used[key] = true
+	return value, true
+}
+
+func (r *Replay) miss(key string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !slices.Contains(r.missing, key) {
+		r.missing = append(r.missing, key)
+	}
+}
+
+func (r *Replay) Unused() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	unused := make([]string, 0)
+	for key := range r.answers {
+		if !r.used[key] {
+			unused = append(unused, key)
+		}
+	}
+	slices.Sort(unused)
+	return unused
+}
+
+func (r *Replay) Missing() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return slices.Clone(r.missing)
+}
+
+func (r *Replay) Report() error {
+	problems := make([]string, 0, 2)
+	if missing := r.Missing(); len(missing) > 0 {
+		problems = append(problems, fmt.Sprintf("missing answers: %s", strings.Join(missing, ", ")))
+	}
+	if unused := r.Unused(); len(unused) > 0 {
+		problems = append(problems, fmt.Sprintf("unused answers: %s", strings.Join(unused, ", ")))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("replay incomplete: %s", strings.Join(problems, "; "))
+}
+
+func (m Module) miss(key string) {
+
+	// only keys no source answered are missing
+	for _, source := range m.answers {
+		if replay, ok := source.(*Replay); ok {
+			replay.miss(key)
+		}
+	}
+}
+
+func (m Module) record(q Question, answer string) error {
+	if m.recorder == nil || q.Secret {
+		return nil
+	}
+	return m.recorder.Record(q.key(), text.Strip(q.Label), answer)
+}
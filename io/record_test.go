@@ -0,0 +1,116 @@
+package io
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.yaml")
+
+	// record an interactive session
+	recorder := NewRecorder()
+	m := New(strings.NewReader("demo\nsecret\ny\n"), &bytes.Buffer{}, WithRecorder(recorder))
+	_, err := m.PromptString("Project name")
+	assert.NoError(t, err)
+	_, err = m.PromptPassword("Token")
+	assert.NoError(t, err)
+	assert.True(t, m.Confirm("Create repo?"))
+	assert.NoError(t, recorder.Save(path))
+
+	// secrets are never written
+	raw, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "project_name: \"demo\"\ncreate_repo: \"y\"\n", string(raw))
+
+	// replay unattended
+	replay, err := LoadReplay(path)
+	assert.NoError(t, err)
+	m = New(strings.NewReader(""), &bytes.Buffer{}, WithAnswers(replay), WithInputPolicy(FailMissing))
+	name, err := m.PromptString("Project name")
+	assert.NoError(t, err)
+	assert.Equal(t, "demo", name)
+	_, err = m.PromptPassword("Token")
+	assert.EqualError(t, err, "missing input token")
+	assert.EqualError(t, replay.Report(), "replay incomplete: missing answers: token; unused answers: create_repo")
+
+	// keys answered by a later source are not missing
+	replay = NewReplay(Answers{"project_name": "demo"})
+	m = New(strings.NewReader(""), &bytes.Buffer{}, WithAnswers(replay, Answers{"token": "x"}), WithInputPolicy(FailMissing))
+	_, err = m.PromptPassword("Token")
+	assert.NoError(t, err)
+	_, err = m.PromptString("Project name")
+	assert.NoError(t, err)
+	assert.NoError(t, replay.Report())
+}
+
+func TestRecordDuplicateKeys(t *testing.T) {
+	recorder := NewRecorder()
+	m := New(strings.NewReader("a\nb\nc\n"), &bytes.Buffer{}, WithRecorder(recorder))
+
+	// asking the same prompt again replaces its answer
+	_, err := m.PromptString("Host name")
+	assert.NoError(t, err)
+	_, err = m.PromptString("Host name")
+	assert.NoError(t, err)
+	assert.Equal(t, Answers{"host_name": "b"}, recorder.Answers())
+
+	// different prompts must not share a key
+	_, err = m.PromptString("Host-name?")
+	assert.EqualError(t, err, `duplicate prompt key host_name for "Host name" and "Host-name?"`)
+}
+
+func TestReplayNumericLabels(t *testing.T) {
+	choices := []Choice[string]{{Label: "2", Value: "two"}, {Label: "1", Value: "one"}}
+
+	// recorded labels win over positions
+	recorder := NewRecorder()
+	m := New(strings.NewReader("1\n2\n"), &bytes.Buffer{}, WithRecorder(recorder))
+	value, err := Choose(m, "version", choices)
+	assert.NoError(t, err)
+	assert.Equal(t, "one", value)
+	values, err := MultiSelect(m, "versions", choices)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"two"}, values)
+
+	m = New(strings.NewReader(""), &bytes.Buffer{}, WithAnswers(recorder.Answers()), WithInputPolicy(FailMissing))
+	value, err = Choose(m, "version", choices)
+	assert.NoError(t, err)
+	assert.Equal(t, "one", value)
+	values, err = MultiSelect(m, "versions", choices)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"two"}, values)
+
+	// defaults name the preselected labels
+	choices[1].Selected = true
+	m = New(strings.NewReader(""), &bytes.Buffer{}, WithInputPolicy(UseDefaults))
+	values, err = MultiSelect(m, "versions", choices)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"one"}, values)
+}
+
+func TestReplayCommaLabels(t *testing.T) {
+	choices := []Choice[int]{{Label: "a, b", Value: 1}, {Label: "c", Value: 2}, {Label: "a", Value: 3}}
+
+	// recorded labels keep their commas
+	recorder := NewRecorder()
+	m := New(strings.NewReader("1,2\n"), &bytes.Buffer{}, WithRecorder(recorder))
+	values, err := MultiSelect(m, "letters", choices)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, values)
+	assert.Equal(t, `["a, b","c"]`, recorder.Answers()["letters"])
+
+	m = New(strings.NewReader(""), &bytes.Buffer{}, WithAnswers(recorder.Answers()), WithInputPolicy(FailMissing))
+	values, err = MultiSelect(m, "letters", choices)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, values)
+
+	// malformed arrays are rejected
+	m = New(strings.NewReader(""), &bytes.Buffer{}, WithAnswers(Answers{"letters": `["a, b"`}), WithInputPolicy(FailMissing))
+	_, err = MultiSelect(m, "letters", choices)
+	assert.Error(t, err)
+}
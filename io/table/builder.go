@@ -5,18 +5,35 @@ import (
 )
 
 type Option func(config *Config)
+type CellOption func(config *cellConfig)
 
 type CellRenderer[T any] func(record T, header string) (string, string)
+type ColRenderer[T any] func(record T) (string, string)
+type RowRenderer[T any] func(record T, rowIndex int) string
 
 type Config struct {
 	HideHeaders bool
 	Indention   string
+	ASCII       bool
+}
+
+type cellConfig struct {
+	span int
+}
+
+type headerGroup struct {
+	title   string
+	headers []string
 }
 
 type Builder[T any] struct {
 	renderContext *renderContext[T]
 	headers       []string
+	groups        []headerGroup
+	rules         map[string][]Rule
 	rows          []row[T]
+	layout        []row[T]
+	tree          treeConfig[T]
 	cells         [][]Cell
 	footer        map[string]Cell
 	config        *Config
@@ -30,8 +47,11 @@ func NewBuilder[T any](options ...Option) *Builder[T] {
 			cellRenderer: func(value T, header string) (string, string) {
 				return "%s", fmt.Sprintf("%v", value)
 			},
+			colRenderers: make(map[string]ColRenderer[T]),
+			graphics:     make(map[string]graphic[T]),
 		},
 
+		rules:  make(map[string][]Rule),
 		footer: make(map[string]Cell),
 		config: &Config{
 			HideHeaders: false,
@@ -48,11 +68,59 @@ func (b *Builder[T]) AddHeaders(row ...string) *Builder[T] {
 	return b
 }
 
+func WithSpan(columns int) CellOption {
+	return func(config *cellConfig) {
+		config.span = columns
+	}
+}
+
+func WithFullSpan() CellOption {
+	return func(config *cellConfig) {
+		config.span = spanAll
+	}
+}
+
+func (b *Builder[T]) AddHeaderGroup(title string, headers ...string) *Builder[T] {
+	b.groups = append(b.groups, headerGroup{
+		title:   title,
+		headers: headers,
+	})
+	return b
+}
+
 func (b *Builder[T]) AddCellFormatter(cf CellRenderer[T]) *Builder[T] {
 	b.renderContext.cellRenderer = cf
 	return b
 }
 
+func (b *Builder[T]) AddColFormatter(header string, cf ColRenderer[T]) *Builder[T] {
+	b.renderContext.colRenderers[header] = cf
+	return b
+}
+
+func (b *Builder[T]) AddRowFormatter(rf RowRenderer[T]) *Builder[T] {
+	b.renderContext.rowRenderer = rf
+	return b
+}
+
+func (b *Builder[T]) AddZebra(style string) *Builder[T] {
+	b.renderContext.zebraStyle = style
+	return b
+}
+
+func (b *Builder[T]) AddHighlight(predicate func(record T) bool, style string) *Builder[T] {
+	b.renderContext.highlights = append(b.renderContext.highlights, highlightRule[T]{
+		predicate: predicate,
+		style:     style,
+	})
+	return b
+}
+
+func (b *Builder[T]) AddRules(header string, rules ...Rule) *Builder[T] {
+	b.rules[header] = append(b.rules[header], rules...)
+	return b
+}
+
 func (b *Builder[T]) AddRow(rows ...T) *Builder[T] {
 	for _, r := range rows {
 		b.rows = append(b.rows, dataRow[T]{
@@ -69,21 +137,27 @@ func (b *Builder[T]) AddSeparator(char string) *Builder[T] {
 	return b
 }
 
-func (b *Builder[T]) AddCustomCell(header, value, style string) *Builder[T] {
+func (b *Builder[T]) AddTitleRow(value, style string) *Builder[T] {
+	b.rows = append(b.rows, titleRow[T]{
+		cell: dataCell{
+			value: value,
+			style: style,
+		},
+	})
+	return b
+}
+
+func (b *Builder[T]) AddCustomCell(header, value, style string, options ...CellOption) *Builder[T] {
 	cr := customRow[T]{
-		data: make(map[string]dataCell),
+		data: make(map[string]Cell),
 	}
 	b.rows = append(b.rows, cr)
-	return b.AppendCustomCell(header, value, style)
+	return b.AppendCustomCell(header, value, style, options...)
 }
 
-func (b *Builder[T]) AppendCustomCell(header, value, style string) *Builder[T] {
+func (b *Builder[T]) AppendCustomCell(header, value, style string, options ...CellOption) *Builder[T] {
 	cr := b.ensureCustomRow()
-	cell := dataCell{
-		value: value,
-		style: style,
-	}
-	cr.data[header] = cell
+	cr.data[header] = newCell(value, style, options...)
 	b.rows[len(b.rows)-1] = cr
 	return b
 }
@@ -103,7 +177,7 @@ func (b *Builder[T]) ensureCustomRow() customRow[T] {
 	// table is empty
 	if len(b.rows) == 0 {
 		cr := customRow[T]{
-			data: make(map[string]dataCell),
+			data: make(map[string]Cell),
 		}
 		b.rows = append(b.rows, cr)
 		return cr
@@ -113,7 +187,7 @@ func (b *Builder[T]) ensureCustomRow() customRow[T] {
 	cr, ok := b.rows[len(b.rows)-1].(customRow[T])
 	if !ok {
 		cr = customRow[T]{
-			data: make(map[string]dataCell),
+			data: make(map[string]Cell),
 		}
 		b.rows = append(b.rows, cr)
 	}
@@ -121,12 +195,8 @@ func (b *Builder[T]) ensureCustomRow() customRow[T] {
 	return cr
 }
 
-func (b *Builder[T]) AddFooterCell(header, value, style string) *Builder[T] {
-	cell := dataCell{
-		value: value,
-		style: style,
-	}
-	b.footer[header] = cell
+func (b *Builder[T]) AddFooterCell(header, value, style string, options ...CellOption) *Builder[T] {
+	b.footer[header] = newCell(value, style, options...)
 	return b
 }
 
@@ -135,23 +205,63 @@ func (b *Builder[T]) Build() string {
 	// create cells
 	b.createCells()
 
-	// determine max width of each column
+	// determine max width and position of each column
 	maxWidths := b.getMaxWidths()
+	offsets := b.columnOffsets(maxWidths)
 
 	// render
 	var table string
 	table += b.config.Indention
 
 	// print headers
-	table += b.renderHeaders(maxWidths)
+	table += b.renderHeaders(maxWidths, offsets)
 
 	// print rows
 	table += fmt.Sprintf("\n%s", b.config.Indention)
-	table += b.renderRows(maxWidths)
+	table += b.renderRows(maxWidths, offsets)
 
 	// print footer
-	table += b.renderFooter(maxWidths)
+	table += b.renderFooter(maxWidths, offsets)
 	table += "\n"
 
 	return table
 }
+
+func newCell(value, style string, options ...CellOption) Cell {
+	config := &cellConfig{
+		span: 1,
+	}
+	for _, opt := range options {
+		opt(config)
+	}
+	cell := dataCell{
+		value: value,
+		style: style,
+	}
+	if config.span == 1 {
+		return cell
+	}
+	return spanCell{
+		Cell: cell,
+		span: config.span,
+	}
+}
+
+func (g headerGroup) resolve(headers []string) (int, int) {
+	start, end := -1, -1
+	for i, header := range headers {
+		for _, member := range g.headers {
+			if header != member {
+				continue
+			}
+			if start < 0 {
+				start = i
+			}
+			end = i
+		}
+	}
+	if start < 0 {
+		return 0, 0
+	}
+	return start, end - start + 1
+}
@@ -2,6 +2,7 @@ package table
 
 import (
 	"fmt"
+	"github.com/rollicks-c/term/internal/text"
 	"strings"
 )
 
@@ -11,20 +12,39 @@ type Cell interface {
 }
 
 type dataCell struct {
-	value string
-	style string
+	value  string
+	style  string
+	styled bool
+	prefix string
 }
 type separatorCell struct {
 	char string
 }
+type spanCell struct {
+	Cell
+	span int
+}
+type prefixCell struct {
+	Cell
+	prefix string
+}
+
+const spanAll = -1
 
 func (dc dataCell) Render(width int) string {
-	valuePadded := fmt.Sprintf("%-*s", width, dc.value)
-	content := fmt.Sprintf(dc.style, valuePadded)
+	valuePadded := text.Pad(dc.value, width-text.Width(dc.prefix))
+	content := dc.prefix + fmt.Sprintf(dc.style, valuePadded)
 	return content
 }
 func (dc dataCell) Len() int {
-	return len(dc.value)
+	return text.Width(dc.prefix) + text.Width(dc.value)
+}
+
+func (pc prefixCell) Render(width int) string {
+	return pc.prefix + pc.Cell.Render(width-text.Width(pc.prefix))
+}
+func (pc prefixCell) Len() int {
+	return text.Width(pc.prefix) + pc.Cell.Len()
 }
 
 func (sc separatorCell) Render(width int) string {
@@ -34,3 +54,24 @@ func (sc separatorCell) Render(width int) string {
 func (sc separatorCell) Len() int {
 	return 1
 }
+
+func emptyCell() Cell {
+	return dataCell{
+		value: "",
+		style: "%s",
+	}
+}
+
+func spanOf(cell Cell, remaining int) int {
+	sc, ok := cell.(spanCell)
+	if !ok {
+		return 1
+	}
+	if sc.span == spanAll || sc.span > remaining {
+		return remaining
+	}
+	if sc.span < 1 {
+		return 1
+	}
+	return sc.span
+}
@@ -0,0 +1,303 @@
+package table
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/rollicks-c/term/internal/text"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+type DetailOption func(config *detailConfig)
+
+type DetailRenderer func(path, value string) (string, string)
+
+type detailConfig struct {
+	fields    []string
+	omit      []string
+	omitEmpty bool
+	keyStyle  string
+	renderer  DetailRenderer
+	indention string
+	divider   string
+}
+
+type detailEntry struct {
+	key   string
+	value any
+}
+
+func WithFields(paths ...string) DetailOption {
+	return func(config *detailConfig) {
+		config.fields = paths
+	}
+}
+
+func WithOmit(paths ...string) DetailOption {
+	return func(config *detailConfig) {
+		config.omit = append(config.omit, paths...)
+	}
+}
+
+func WithOmitEmpty() DetailOption {
+	return func(config *detailConfig) {
+		config.omitEmpty = true
+	}
+}
+
+func WithKeyStyle(style string) DetailOption {
+	return func(config *detailConfig) {
+		config.keyStyle = style
+	}
+}
+
+func WithValueFormatter(renderer DetailRenderer) DetailOption {
+	return func(config *detailConfig) {
+		config.renderer = renderer
+	}
+}
+
+func WithDetailIndention(chars string) DetailOption {
+	return func(config *detailConfig) {
+		config.indention = chars
+	}
+}
+
+func WithDivider(char string) DetailOption {
+	return func(config *detailConfig) {
+		config.divider = char
+	}
+}
+
+func Detail(obj any, options ...DetailOption) string {
+	config := newDetailConfig(options...)
+	entries, err := toEntries(obj)
+	if err != nil {
+		return config.indention + fmt.Sprintf("error: %v", err)
+	}
+	return strings.Join(config.render(entries), "\n")
+}
+
+func DetailList[T any](records []T, options ...DetailOption) string {
+
+	// render records
+	config := newDetailConfig(options...)
+	blocks := make([][]string, len(records))
+	width := 0
+	for i, record := range records {
+		entries, err := toEntries(record)
+		if err != nil {
+			blocks[i] = []string{config.indention + fmt.Sprintf("error: %v", err)}
+			continue
+		}
+		blocks[i] = config.render(entries)
+		for _, line := range blocks[i] {
+			width = max(width, text.Width(line)-text.Width(config.indention))
+		}
+	}
+
+	// separate by dividers
+	divider := config.indention + strings.Repeat(config.divider, width)
+	out := make([]string, 0)
+	for i, block := range blocks {
+		if i > 0 {
+			out = append(out, divider)
+		}
+		out = append(out, block...)
+	}
+
+	return strings.Join(out, "\n")
+}
+
+func newDetailConfig(options ...DetailOption) *detailConfig {
+	config := &detailConfig{
+		keyStyle: "%s",
+		renderer: func(path, value string) (string, string) {
+			return "%s", value
+		},
+		divider: "-",
+	}
+	for _, opt := range options {
+		opt(config)
+	}
+	return config
+}
+
+func (c *detailConfig) render(entries []detailEntry) []string {
+	return c.renderSection(entries, "", c.indention)
+}
+
+func (c *detailConfig) renderSection(entries []detailEntry, parent, indention string) []string {
+
+	// select and order fields
+	entries = c.arrange(entries, parent)
+
+	// align keys of this level
+	keyWidth := 0
+	for _, e := range entries {
+		keyWidth = max(keyWidth, text.Width(e.key)+1)
+	}
+
+	lines := make([]string, 0, len(entries))
+	for _, e := range entries {
+		path := joinPath(parent, e.key)
+		key := fmt.Sprintf(c.keyStyle, e.key+":")
+
+		// nested sections
+		if nested, ok := e.value.([]detailEntry); ok {
+			lines = append(lines, indention+key)
+			lines = append(lines, c.renderSection(nested, path, indention+"  ")...)
+			continue
+		}
+
+		// values, continuation lines are aligned to the value column
+		style, value := c.renderer(path, e.value.(string))
+		padding := strings.Repeat(" ", keyWidth-text.Width(e.key+":")+2)
+		valueLines := strings.Split(value, "\n")
+		lines = append(lines, indention+key+padding+fmt.Sprintf(style, valueLines[0]))
+		for _, line := range valueLines[1:] {
+			lines = append(lines, indention+strings.Repeat(" ", keyWidth+2)+fmt.Sprintf(style, line))
+		}
+	}
+
+	return lines
+}
+
+func (c *detailConfig) arrange(entries []detailEntry, parent string) []detailEntry {
+
+	// omit fields
+	kept := make([]detailEntry, 0, len(entries))
+	for _, e := range entries {
+		if slices.Contains(c.omit, joinPath(parent, e.key)) {
+			continue
+		}
+		if c.omitEmpty && isEmptyValue(e.value) {
+			continue
+		}
+		kept = append(kept, e)
+	}
+
+	// listed fields first, the rest in source order
+	rank := func(e detailEntry) int {
+		if i := slices.Index(c.fields, joinPath(parent, e.key)); i >= 0 {
+			return i
+		}
+		return len(c.fields)
+	}
+	slices.SortStableFunc(kept, func(a, b detailEntry) int {
+		return rank(a) - rank(b)
+	})
+
+	return kept
+}
+
+func toEntries(obj any) ([]detailEntry, error) {
+
+	// structs, maps and flat objects share the json representation
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	// decode keeping field order
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	value, err := decodeValue(dec)
+	if err != nil {
+		return nil, err
+	}
+	entries, ok := value.([]detailEntry)
+	if !ok {
+		return nil, fmt.Errorf("expected object, got %s", reflect.TypeOf(obj))
+	}
+
+	return entries, nil
+}
+
+func decodeValue(dec *json.Decoder) (any, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := token.(type) {
+	case json.Delim:
+		if t == '{' {
+			return decodeObject(dec)
+		}
+		return decodeArray(dec)
+	case nil:
+		return "null", nil
+	default:
+		return fmt.Sprintf("%v", t), nil
+	}
+}
+
+func decodeObject(dec *json.Decoder) (any, error) {
+	entries := make([]detailEntry, 0)
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		value, err := decodeValue(dec)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, detailEntry{key: token.(string), value: value})
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func decodeArray(dec *json.Decoder) (any, error) {
+
+	// decode elements
+	items := make([]any, 0)
+	for dec.More() {
+		value, err := decodeValue(dec)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, value)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	// scalar lists are joined, structured lists become indexed sections
+	scalars := make([]string, 0, len(items))
+	entries := make([]detailEntry, 0, len(items))
+	for i, item := range items {
+		if s, ok := item.(string); ok {
+			scalars = append(scalars, s)
+		}
+		entries = append(entries, detailEntry{key: fmt.Sprintf("[%d]", i), value: item})
+	}
+	if len(scalars) == len(items) {
+		return strings.Join(scalars, ", "), nil
+	}
+
+	return entries, nil
+}
+
+func isEmptyValue(value any) bool {
+	switch v := value.(type) {
+	case string:
+		return v == "" || v == "null" || v == "0" || v == "false"
+	case []detailEntry:
+		return len(v) == 0
+	}
+	return false
+}
+
+func joinPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
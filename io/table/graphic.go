@@ -0,0 +1,274 @@
+package table
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const defaultGraphicWidth = 10
+
+var (
+	barBlocks        = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+	sparkBlocks      = []rune("▁▂▃▄▅▆▇█")
+	sparkBlocksASCII = []rune("_.-~^")
+)
+
+type GraphicOption func(config *graphicConfig)
+
+type graphicConfig struct {
+	width int
+	style string
+	max   float64
+}
+
+type graphic[T any] interface {
+	prepare(records []T)
+	cell(record T, ascii bool) Cell
+}
+
+type barGraphic[T any] struct {
+	value  func(record T) float64
+	config graphicConfig
+	max    float64
+}
+
+type progressGraphic[T any] struct {
+	ratio  func(record T) float64
+	config graphicConfig
+}
+
+type sparkGraphic[T any] struct {
+	series func(record T) []float64
+	config graphicConfig
+}
+
+type barCell struct {
+	ratio    float64
+	minWidth int
+	style    string
+	label    string
+	track    bool
+	ascii    bool
+}
+
+type sparkCell struct {
+	series   []float64
+	minWidth int
+	style    string
+	ascii    bool
+}
+
+func WithWidth(width int) GraphicOption {
+	return func(config *graphicConfig) {
+		config.width = width
+	}
+}
+
+func WithGraphicStyle(style string) GraphicOption {
+	return func(config *graphicConfig) {
+		config.style = style
+	}
+}
+
+func WithScale(max float64) GraphicOption {
+	return func(config *graphicConfig) {
+		config.max = max
+	}
+}
+
+func (b *Builder[T]) AddBar(header string, value func(record T) float64, options ...GraphicOption) *Builder[T] {
+	b.renderContext.graphics[header] = &barGraphic[T]{
+		value:  value,
+		config: newGraphicConfig(options...),
+	}
+	return b
+}
+
+func (b *Builder[T]) AddProgress(header string, ratio func(record T) float64, options ...GraphicOption) *Builder[T] {
+	b.renderContext.graphics[header] = &progressGraphic[T]{
+		ratio:  ratio,
+		config: newGraphicConfig(options...),
+	}
+	return b
+}
+
+func (b *Builder[T]) AddSparkline(header string, series func(record T) []float64, options ...GraphicOption) *Builder[T] {
+	b.renderContext.graphics[header] = &sparkGraphic[T]{
+		series: series,
+		config: newGraphicConfig(options...),
+	}
+	return b
+}
+
+func newGraphicConfig(options ...GraphicOption) graphicConfig {
+	config := graphicConfig{
+		width: defaultGraphicWidth,
+		style: "%s",
+	}
+	for _, opt := range options {
+		opt(&config)
+	}
+	return config
+}
+
+func (g *barGraphic[T]) prepare(records []T) {
+
+	// fixed scale
+	if g.config.max > 0 {
+		g.max = g.config.max
+		return
+	}
+
+	// scale to largest value
+	g.max = 0
+	for _, record := range records {
+		if value := g.value(record); finite(value) {
+			g.max = math.Max(g.max, value)
+		}
+	}
+}
+
+func (g *barGraphic[T]) cell(record T, ascii bool) Cell {
+	ratio := 0.0
+	if g.max > 0 {
+		ratio = g.value(record) / g.max
+	}
+	return barCell{
+		ratio:    ratio,
+		minWidth: g.config.width,
+		style:    g.config.style,
+		ascii:    ascii,
+	}
+}
+
+func (g *progressGraphic[T]) prepare(records []T) {
+}
+
+func (g *progressGraphic[T]) cell(record T, ascii bool) Cell {
+	ratio := g.ratio(record)
+	return barCell{
+		ratio:    ratio,
+		minWidth: g.config.width,
+		style:    g.config.style,
+		label:    fmt.Sprintf(" %3.0f%%", math.Max(0, math.Min(1, ratio))*100),
+		track:    true,
+		ascii:    ascii,
+	}
+}
+
+func (g *sparkGraphic[T]) prepare(records []T) {
+}
+
+func (g *sparkGraphic[T]) cell(record T, ascii bool) Cell {
+	return sparkCell{
+		series:   g.series(record),
+		minWidth: g.config.width,
+		style:    g.config.style,
+		ascii:    ascii,
+	}
+}
+
+func (c barCell) Render(width int) string {
+
+	// scale to available width in eighths of a block
+	barWidth := width - len(c.label)
+	ratio := math.Max(0, math.Min(1, c.ratio))
+	if math.IsNaN(ratio) {
+		ratio = 0
+	}
+	eighths := int(math.Round(ratio * float64(barWidth) * 8))
+
+	// draw
+	var bar string
+	if c.ascii {
+		bar = strings.Repeat("#", (eighths+4)/8)
+	} else {
+		bar = strings.Repeat("█", eighths/8) + barBlocks[eighths%8]
+	}
+	filled := len([]rune(bar))
+	track := " "
+	if c.track {
+		track = "░"
+		if c.ascii {
+			track = "-"
+		}
+	}
+	bar = fmt.Sprintf(c.style, bar) + strings.Repeat(track, barWidth-filled)
+
+	return bar + c.label
+}
+
+func (c barCell) Len() int {
+	return c.minWidth + len(c.label)
+}
+
+func (c sparkCell) Render(width int) string {
+
+	// nothing to draw
+	blocks := sparkBlocks
+	if c.ascii {
+		blocks = sparkBlocksASCII
+	}
+	series := resample(c.series, width)
+	if len(series) == 0 {
+		return strings.Repeat(" ", width)
+	}
+
+	// scale between min and max of the finite values, others stay blank
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, v := range series {
+		if finite(v) {
+			low, high = math.Min(low, v), math.Max(high, v)
+		}
+	}
+	line := make([]rune, len(series))
+	for i, v := range series {
+		if !finite(v) {
+			line[i] = ' '
+			continue
+		}
+		level := 0
+		if high > low {
+			level = int(math.Round((v - low) / (high - low) * float64(len(blocks)-1)))
+		}
+		line[i] = blocks[level]
+	}
+
+	return fmt.Sprintf(c.style, string(line)) + strings.Repeat(" ", width-len(line))
+}
+
+func (c sparkCell) Len() int {
+	return min(c.minWidth, len(c.series))
+}
+
+func resample(series []float64, width int) []float64 {
+
+	// fits already
+	if len(series) <= width {
+		return series
+	}
+
+	// average buckets
+	out := make([]float64, width)
+	for i := range out {
+		start := i * len(series) / width
+		end := (i + 1) * len(series) / width
+		sum, count := 0.0, 0
+		for _, v := range series[start:end] {
+			if finite(v) {
+				sum += v
+				count++
+			}
+		}
+		out[i] = math.NaN()
+		if count > 0 {
+			out[i] = sum / float64(count)
+		}
+	}
+	return out
+}
+
+func finite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
@@ -0,0 +1,225 @@
+package table
+
+import (
+	"fmt"
+	"slices"
+)
+
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+const defaultRowHeader = "key"
+
+type Aggregator[V any] func(values []V) V
+
+type PivotOption func(config *pivotConfig)
+
+type pivotConfig struct {
+	rowHeader string
+	columns   []string
+	fill      string
+}
+
+func WithRowHeader(name string) PivotOption {
+	return func(config *pivotConfig) {
+		config.rowHeader = name
+	}
+}
+
+func WithColumns(keys ...string) PivotOption {
+	return func(config *pivotConfig) {
+		config.columns = keys
+	}
+}
+
+func WithFill(value string) PivotOption {
+	return func(config *pivotConfig) {
+		config.fill = value
+	}
+}
+
+func Sum[V Number](values []V) V {
+	var sum V
+	for _, v := range values {
+		sum += v
+	}
+	return sum
+}
+
+// Count only fits Pivot for int values, use PivotCount for other value types
+func Count[V any](values []V) int {
+	return len(values)
+}
+
+func Avg[V Number](values []V) V {
+	if len(values) == 0 {
+		return 0
+	}
+	return Sum(values) / V(len(values))
+}
+
+func Min[V Number](values []V) V {
+	if len(values) == 0 {
+		return 0
+	}
+	result := values[0]
+	for _, v := range values[1:] {
+		result = min(result, v)
+	}
+	return result
+}
+
+func Max[V Number](values []V) V {
+	if len(values) == 0 {
+		return 0
+	}
+	result := values[0]
+	for _, v := range values[1:] {
+		result = max(result, v)
+	}
+	return result
+}
+
+func Pivot[T any, V any](b *Builder[T], rowKey, colKey func(T) string, value func(T) V, agg Aggregator[V], options ...PivotOption) *Builder[FlatObject] {
+
+	// init config
+	config := &pivotConfig{
+		rowHeader: defaultRowHeader,
+		fill:      "",
+	}
+	for _, opt := range options {
+		opt(config)
+	}
+
+	// group values by row and column key, keeping first appearance order
+	rowKeys := make([]string, 0)
+	colKeys := make([]string, 0)
+	groups := make(map[string]map[string][]V)
+	for _, record := range b.records() {
+		rk, ck := rowKey(record), colKey(record)
+		if _, ok := groups[rk]; !ok {
+			groups[rk] = make(map[string][]V)
+			rowKeys = append(rowKeys, rk)
+		}
+		if !slices.Contains(colKeys, ck) {
+			colKeys = append(colKeys, ck)
+		}
+		groups[rk][ck] = append(groups[rk][ck], value(record))
+	}
+	if config.columns != nil {
+		colKeys = config.columns
+	}
+	if slices.Contains(colKeys, config.rowHeader) {
+		return createErrorTable(fmt.Errorf("column %s clashes with row header", config.rowHeader))
+	}
+
+	// aggregate
+	pivot := NewBuilder[FlatObject]().
+		AddHeaders(config.rowHeader).
+		AddHeaders(colKeys...)
+	pivot.config = b.copyConfig()
+	for _, rk := range rowKeys {
+		record := FlatObject{config.rowHeader: rk}
+		for ck, values := range groups[rk] {
+			record[ck] = agg(values)
+		}
+		pivot.AddRow(record)
+	}
+
+	// fill missing cells
+	cf := func(record FlatObject, header string) (string, string) {
+		v, ok := record[header]
+		if !ok {
+			return "%s", config.fill
+		}
+		return "%s", fmt.Sprintf("%v", v)
+	}
+	pivot.AddCellFormatter(cf)
+
+	return pivot
+}
+
+func PivotCount[T any](b *Builder[T], rowKey, colKey func(T) string, options ...PivotOption) *Builder[FlatObject] {
+	one := func(T) int { return 1 }
+	return Pivot(b, rowKey, colKey, one, Sum[int], options...)
+}
+
+func (b *Builder[T]) Transpose(labelHeader string) *Builder[FlatObject] {
+
+	// render cells of data rows
+	b.createCells()
+	records := make([][]Cell, 0, len(b.layout))
+	for rowIndex, r := range b.layout {
+		if _, ok := r.(dataRow[T]); ok {
+			records = append(records, b.cells[rowIndex])
+		}
+	}
+
+	// every record becomes a column
+	labelIndex := slices.Index(b.headers, labelHeader)
+	labels := make([]string, len(records))
+	for i, cells := range records {
+		label := fmt.Sprintf("%d", i+1)
+		if labelIndex >= 0 {
+			label = toViewCell(cells[labelIndex]).value
+		}
+		for label == labelHeader || slices.Contains(labels[:i], label) {
+			label += "'"
+		}
+		labels[i] = label
+	}
+
+	// every remaining header becomes a row
+	styles := make(map[string]map[string]string)
+	transposed := NewBuilder[FlatObject]().
+		AddHeaders(labelHeader).
+		AddHeaders(labels...)
+	transposed.config = b.copyConfig()
+	for colIndex, header := range b.headers {
+		if colIndex == labelIndex {
+			continue
+		}
+		record := FlatObject{labelHeader: header}
+		styles[header] = make(map[string]string)
+		for i, cells := range records {
+			cell := toViewCell(cells[colIndex])
+			record[labels[i]] = cell.value
+			styles[header][labels[i]] = cell.style
+		}
+		transposed.AddRow(record)
+	}
+
+	// keep original styles
+	cf := func(record FlatObject, header string) (string, string) {
+		style, ok := styles[fmt.Sprintf("%v", record[labelHeader])][header]
+		if !ok {
+			style = "%s"
+		}
+		return style, fmt.Sprintf("%v", record[header])
+	}
+	transposed.AddCellFormatter(cf)
+
+	return transposed
+}
+
+func (b *Builder[T]) records() []T {
+	return recordsOf(b.rows)
+}
+
+func recordsOf[T any](rows []row[T]) []T {
+	records := make([]T, 0, len(rows))
+	for _, r := range rows {
+		if dr, ok := r.(dataRow[T]); ok {
+			records = append(records, dr.record)
+		}
+	}
+	return records
+}
+
+func (b *Builder[T]) copyConfig() *Config {
+	config := *b.config
+	return &config
+}
@@ -1,10 +1,11 @@
 package table
 
 import (
-	"fmt"
-	"strings"
+	"github.com/rollicks-c/term/internal/text"
 )
 
+const tabWidth = 8
+
 func (b *Builder[T]) getMaxWidths() []int {
 
 	// determine max width of each column
@@ -15,10 +16,10 @@ func (b *Builder[T]) getMaxWidths() []int {
 		if b.config.HideHeaders {
 			maxWidths[i] = 0
 		} else {
-			maxWidths[i] = len(header)
+			maxWidths[i] = text.Width(header)
 		}
 		footer, ok := b.footer[header]
-		if !ok {
+		if !ok || spanOf(footer, len(b.headers)-i) > 1 {
 			continue
 		}
 		if footer.Len() > maxWidths[i] {
@@ -29,121 +30,223 @@ func (b *Builder[T]) getMaxWidths() []int {
 	// cells
 	for _, row := range b.cells {
 		for colIndex, cell := range row {
+			if spanOf(cell, len(b.headers)-colIndex) > 1 {
+				continue
+			}
 			if cell.Len() > maxWidths[colIndex] {
 				maxWidths[colIndex] = cell.Len()
 			}
 		}
 	}
 
+	// widen columns for spanning cells
+	b.fitSpans(maxWidths)
+
 	return maxWidths
 }
 
+func (b *Builder[T]) fitSpans(maxWidths []int) {
+
+	// gather lines with spanning cells, titles may overflow
+	lines := make([][]Cell, 0, len(b.cells)+2)
+	for rowIndex, row := range b.cells {
+		if _, ok := b.layout[rowIndex].(titleRow[T]); !ok {
+			lines = append(lines, row)
+		}
+	}
+	lines = append(lines, b.footerCells())
+	if !b.config.HideHeaders {
+		lines = append(lines, b.groupCells())
+	}
+
+	// grow last column of a span until its content fits
+	for b.growSpan(lines, maxWidths) {
+	}
+}
+
+func (b *Builder[T]) growSpan(lines [][]Cell, maxWidths []int) bool {
+	offsets := b.columnOffsets(maxWidths)
+	for _, line := range lines {
+		for colIndex := 0; colIndex < len(line); {
+			span := spanOf(line[colIndex], len(b.headers)-colIndex)
+			width := spanWidth(colIndex, span, maxWidths, offsets)
+			if deficit := line[colIndex].Len() - width; deficit > 0 {
+				maxWidths[colIndex+span-1] += deficit
+				return true
+			}
+			colIndex += span
+		}
+	}
+	return false
+}
+
+func (b *Builder[T]) columnOffsets(maxWidths []int) []int {
+
+	// columns are separated by tabs, so a column starts at the next tab stop
+	offsets := make([]int, len(maxWidths))
+	pos := text.Width(text.ExpandTabs(b.config.Indention, tabWidth))
+	for i, width := range maxWidths {
+		offsets[i] = pos
+		pos = (pos+width)/tabWidth*tabWidth + tabWidth
+	}
+
+	return offsets
+}
+
+func spanWidth(colIndex, span int, maxWidths, offsets []int) int {
+	last := colIndex + span - 1
+	return offsets[last] + maxWidths[last] - offsets[colIndex]
+}
+
 func (b *Builder[T]) createCells() {
-	b.cells = make([][]Cell, len(b.rows))
-	for rowIndex := range b.rows {
+	b.layout = b.expandRows()
+	b.cells = make([][]Cell, len(b.layout))
+	ctx := *b.renderContext
+	ctx.ascii = b.config.ASCII
+	if len(b.headers) > 0 {
+		ctx.treeHeader = b.headers[0]
+	}
+	for _, g := range ctx.graphics {
+		g.prepare(recordsOf(b.layout))
+	}
+	for rowIndex := range b.layout {
 		b.cells[rowIndex] = make([]Cell, len(b.headers))
-		record := b.rows[rowIndex]
+		record := b.layout[rowIndex]
 		for colIndex, header := range b.headers {
-			cell := record.RenderCell(*b.renderContext, header)
+			cell := record.RenderCell(ctx, header)
 			b.cells[rowIndex][colIndex] = cell
 		}
+		b.clearSpanned(b.cells[rowIndex])
+		if _, ok := record.(dataRow[T]); ok {
+			ctx.rowIndex++
+		}
 	}
+	b.applyRules()
 }
 
-func (b *Builder[T]) renderHeaders(maxWidths []int) string {
+func (b *Builder[T]) clearSpanned(cells []Cell) {
+	for colIndex := 0; colIndex < len(cells); {
+		span := spanOf(cells[colIndex], len(cells)-colIndex)
+		for covered := colIndex + 1; covered < colIndex+span; covered++ {
+			cells[covered] = emptyCell()
+		}
+		colIndex += span
+	}
+}
 
-	// no headers
-	if b.config.HideHeaders {
-		return ""
+func (b *Builder[T]) groupCells() []Cell {
+	cells := make([]Cell, len(b.headers))
+	for i := range cells {
+		cells[i] = emptyCell()
+	}
+	for _, group := range b.groups {
+		start, span := group.resolve(b.headers)
+		if span == 0 {
+			continue
+		}
+		cells[start] = spanCell{
+			Cell: dataCell{value: group.title, style: "%s"},
+			span: span,
+		}
 	}
+	return cells
+}
 
-	// render headers
-	out := ""
+func (b *Builder[T]) footerCells() []Cell {
+	cells := make([]Cell, len(b.headers))
 	for i, header := range b.headers {
-		padLen := maxWidths[i]
-		exp := fmt.Sprintf("%-*s", padLen, header)
-		out += exp
-		if i < len(b.headers)-1 {
-			out += "\t"
+		cell, ok := b.footer[header]
+		if !ok {
+			cell = emptyCell()
 		}
+		cells[i] = cell
 	}
+	b.clearSpanned(cells)
+	return cells
+}
 
-	// render separator
-	out += fmt.Sprintf("\n%s", b.config.Indention)
-	for i := range b.headers {
-		padLen := maxWidths[i]
-		exp := strings.Repeat("-", padLen)
-		out += exp
-		if i < len(b.headers)-1 {
+func (b *Builder[T]) renderLine(cells []Cell, maxWidths, offsets []int) string {
+	out := ""
+	for colIndex := 0; colIndex < len(b.headers); {
+		span := spanOf(cells[colIndex], len(b.headers)-colIndex)
+		width := spanWidth(colIndex, span, maxWidths, offsets)
+		out += cells[colIndex].Render(width)
+		colIndex += span
+		if colIndex < len(b.headers) {
 			out += "\t"
 		}
 	}
-
 	return out
 }
 
-func (b *Builder[T]) renderRows(maxWidths []int) string {
+func (b *Builder[T]) renderHeaders(maxWidths, offsets []int) string {
 
+	// no headers
+	if b.config.HideHeaders {
+		return ""
+	}
+
+	// render groups
 	out := ""
+	if len(b.groups) > 0 {
+		out += b.renderLine(b.groupCells(), maxWidths, offsets)
+		out += "\n" + b.config.Indention
+	}
 
-	// iterate all cells
-	for rowIndex := range b.rows {
-		for colIndex := range b.headers {
+	// render headers
+	headers := make([]Cell, len(b.headers))
+	separators := make([]Cell, len(b.headers))
+	for i, header := range b.headers {
+		headers[i] = dataCell{value: header, style: "%s"}
+		separators[i] = separatorCell{char: "-"}
+	}
+	out += b.renderLine(headers, maxWidths, offsets)
 
-			// render
-			padLen := maxWidths[colIndex]
-			cell := b.cells[rowIndex][colIndex].Render(padLen)
+	// render separator
+	out += "\n" + b.config.Indention
+	out += b.renderLine(separators, maxWidths, offsets)
 
-			// append
-			out += cell
-			if colIndex < len(b.headers)-1 {
-				out += "\t"
-			}
-		}
-		out += fmt.Sprintf("\n%s", b.config.Indention)
+	return out
+}
+
+func (b *Builder[T]) renderRows(maxWidths, offsets []int) string {
+
+	out := ""
+
+	// iterate all rows
+	for rowIndex := range b.layout {
+		out += b.renderLine(b.cells[rowIndex], maxWidths, offsets)
+		out += "\n" + b.config.Indention
 	}
 
 	return out
 }
 
-func (b *Builder[T]) renderFooter(maxWidths []int) string {
+func (b *Builder[T]) renderFooter(maxWidths, offsets []int) string {
 
 	// no footer
 	if len(b.footer) == 0 {
 		return ""
 	}
 
-	out := ""
-
 	// render separator
-	for i := range b.headers {
-		padLen := maxWidths[i]
-		_, ok := b.footer[b.headers[i]]
-		char := " "
-		if ok {
-			char = "-"
+	cells := b.footerCells()
+	separators := make([]Cell, len(cells))
+	for i, header := range b.headers {
+		var sep Cell = separatorCell{char: " "}
+		if _, ok := b.footer[header]; ok {
+			sep = separatorCell{char: "-"}
 		}
-		out += strings.Repeat(char, padLen)
-		if i < len(b.headers)-1 {
-			out += "\t"
+		if span := spanOf(cells[i], len(b.headers)-i); span > 1 {
+			sep = spanCell{Cell: sep, span: span}
 		}
+		separators[i] = sep
 	}
+	out := b.renderLine(separators, maxWidths, offsets)
 
 	// render footer
-	out += fmt.Sprintf("\n%s", b.config.Indention)
-	for i := range b.headers {
-		padLen := maxWidths[i]
-		cell, ok := b.footer[b.headers[i]]
-		if !ok {
-			exp := strings.Repeat(" ", padLen)
-			out += exp
-		} else {
-			out += cell.Render(padLen)
-		}
-		if i < len(b.headers)-1 {
-			out += "\t"
-		}
-	}
+	out += "\n" + b.config.Indention
+	out += b.renderLine(cells, maxWidths, offsets)
 
 	return out
 }
@@ -1,9 +1,25 @@
 package table
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 type renderContext[T any] struct {
 	cellRenderer CellRenderer[T]
+	colRenderers map[string]ColRenderer[T]
+	rowRenderer  RowRenderer[T]
+	zebraStyle   string
+	highlights   []highlightRule[T]
+	graphics     map[string]graphic[T]
+	rowIndex     int
+	ascii        bool
+	treeHeader   string
+}
+
+type highlightRule[T any] struct {
+	predicate func(record T) bool
+	style     string
 }
 
 type row[T any] interface {
@@ -11,21 +27,53 @@ type row[T any] interface {
 }
 
 type dataRow[T any] struct {
-	record T
+	record     T
+	guide      string
+	hidden     int
+	aggregates map[string]string
 }
 
 func (d dataRow[T]) RenderCell(ctx renderContext[T], header string) Cell {
 
+	// graphics, indented like any other tree column
+	if g, ok := ctx.graphics[header]; ok {
+		cell := g.cell(d.record, ctx.ascii)
+		if header == ctx.treeHeader {
+			return prefixCell{Cell: cell, prefix: d.guide}
+		}
+		return cell
+	}
+
 	// apply formatters
-	style, valueRaw := ctx.cellRenderer(d.record, header)
+	cellStyle, cellValue := ctx.cellRenderer(d.record, header)
+	colStyle, colValue := "", ""
+	if colRenderer, ok := ctx.colRenderers[header]; ok {
+		colStyle, colValue = colRenderer(d.record)
+	}
+	valueRaw := firstNonEmpty(cellValue, colValue)
+	style := resolveStyle(cellStyle, colStyle, ctx.rowStyle(d.record))
+
+	// tree rows
+	if aggregate, ok := d.aggregates[header]; ok {
+		valueRaw = aggregate
+	}
+	prefix := ""
+	if header == ctx.treeHeader {
+		prefix = d.guide
+		if d.hidden > 0 {
+			valueRaw = fmt.Sprintf("%s [+%d]", valueRaw, d.hidden)
+		}
+	}
 
 	// escape
 	valueRaw = strings.ReplaceAll(valueRaw, "\n", "\\n")
 
 	// wrap
 	return dataCell{
-		value: valueRaw,
-		style: style,
+		value:  valueRaw,
+		style:  style,
+		styled: isStyled(cellStyle),
+		prefix: prefix,
 	}
 }
 
@@ -40,16 +88,70 @@ func (s separatorRow[T]) RenderCell(ctx renderContext[T], header string) Cell {
 }
 
 type customRow[T any] struct {
-	data map[string]dataCell
+	data map[string]Cell
 }
 
 func (c customRow[T]) RenderCell(ctx renderContext[T], header string) Cell {
 	data, ok := c.data[header]
 	if !ok {
-		return dataCell{
-			value: "",
-			style: "%s",
-		}
+		return emptyCell()
 	}
 	return data
 }
+
+type titleRow[T any] struct {
+	cell dataCell
+}
+
+func (t titleRow[T]) RenderCell(ctx renderContext[T], header string) Cell {
+	return spanCell{
+		Cell: t.cell,
+		span: spanAll,
+	}
+}
+
+func (ctx renderContext[T]) rowStyle(record T) string {
+
+	// highlighted rows
+	for _, h := range ctx.highlights {
+		if h.predicate(record) {
+			return h.style
+		}
+	}
+
+	// row formatter
+	if ctx.rowRenderer != nil {
+		if style := ctx.rowRenderer(record, ctx.rowIndex); isStyled(style) {
+			return style
+		}
+	}
+
+	// zebra striping
+	if ctx.zebraStyle != "" && ctx.rowIndex%2 == 1 {
+		return ctx.zebraStyle
+	}
+
+	return ""
+}
+
+func resolveStyle(styles ...string) string {
+	for _, style := range styles {
+		if isStyled(style) {
+			return style
+		}
+	}
+	return "%s"
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func isStyled(style string) bool {
+	return style != "" && style != "%s"
+}
@@ -0,0 +1,158 @@
+package table
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Rule func(value string, stats ColumnStats) (string, bool)
+
+type ColumnStats struct {
+	Min   float64
+	Max   float64
+	Count int
+}
+
+var HeatScale = colorScale(46, 82, 118, 154, 190, 226, 220, 214, 208, 202, 196)
+
+func Above[N Number](limit N, style string) Rule {
+	return When(func(v float64) bool {
+		return v > float64(limit)
+	}, style)
+}
+
+func Below[N Number](limit N, style string) Rule {
+	return When(func(v float64) bool {
+		return v < float64(limit)
+	}, style)
+}
+
+func Between[N Number](low, high N, style string) Rule {
+	return When(func(v float64) bool {
+		return v >= float64(low) && v <= float64(high)
+	}, style)
+}
+
+func When(condition func(v float64) bool, style string) Rule {
+	return func(value string, stats ColumnStats) (string, bool) {
+		v, ok := parseNumber(value)
+		if !ok || !condition(v) {
+			return "", false
+		}
+		return style, true
+	}
+}
+
+func Matches(pattern *regexp.Regexp, style string) Rule {
+	return func(value string, stats ColumnStats) (string, bool) {
+		if !pattern.MatchString(value) {
+			return "", false
+		}
+		return style, true
+	}
+}
+
+func Gradient(styles ...string) Rule {
+	return func(value string, stats ColumnStats) (string, bool) {
+
+		// not numeric
+		v, ok := parseNumber(value)
+		if !ok || len(styles) == 0 {
+			return "", false
+		}
+
+		// position between min and max
+		position := 0.0
+		if stats.Max > stats.Min {
+			position = (v - stats.Min) / (stats.Max - stats.Min)
+		}
+		bucket := int(math.Round(position * float64(len(styles)-1)))
+		bucket = min(max(bucket, 0), len(styles)-1)
+
+		return styles[bucket], true
+	}
+}
+
+func (b *Builder[T]) applyRules() {
+	for colIndex, header := range b.headers {
+
+		// no rules for column
+		rules, ok := b.rules[header]
+		if !ok {
+			continue
+		}
+
+		// collect data cells
+		cells := make(map[int]dataCell)
+		for rowIndex, r := range b.layout {
+			if _, ok := r.(dataRow[T]); !ok {
+				continue
+			}
+			if cell, ok := b.cells[rowIndex][colIndex].(dataCell); ok {
+				cells[rowIndex] = cell
+			}
+		}
+
+		// rules override column and row styles, explicit cell styles win
+		stats := columnStats(cells)
+		for rowIndex, cell := range cells {
+			if cell.styled {
+				continue
+			}
+			for _, rule := range rules {
+				if style, ok := rule(cell.value, stats); ok {
+					cell.style = style
+					b.cells[rowIndex][colIndex] = cell
+					break
+				}
+			}
+		}
+	}
+}
+
+func columnStats(cells map[int]dataCell) ColumnStats {
+	stats := ColumnStats{
+		Min: math.Inf(1),
+		Max: math.Inf(-1),
+	}
+	for _, cell := range cells {
+		v, ok := parseNumber(cell.value)
+		if !ok {
+			continue
+		}
+		stats.Min = math.Min(stats.Min, v)
+		stats.Max = math.Max(stats.Max, v)
+		stats.Count++
+	}
+	return stats
+}
+
+func parseNumber(value string) (float64, bool) {
+
+	// plain numbers, percentages and thousands separators, "Inf" and "NaN"
+	// are words rather than numbers
+	value = strings.TrimSpace(value)
+	plain := strings.ReplaceAll(strings.TrimSuffix(value, "%"), ",", "")
+	if v, err := strconv.ParseFloat(plain, 64); err == nil {
+		return v, finite(v)
+	}
+
+	// durations
+	if d, err := time.ParseDuration(value); err == nil {
+		return float64(d), true
+	}
+
+	return 0, false
+}
+
+func colorScale(colors ...int) []string {
+	styles := make([]string, len(colors))
+	for i, color := range colors {
+		styles[i] = fmt.Sprintf("\033[38;5;%dm%%s\033[0m", color)
+	}
+	return styles
+}
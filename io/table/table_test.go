@@ -1,7 +1,10 @@
 package table
 
 import (
+	"fmt"
 	"github.com/stretchr/testify/assert"
+	"math"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -65,3 +68,419 @@ map[key:value key2:2 key3:true]	map[key:value key2:2 key3:true]	map[key:value ke
 	assert.Equal(t, exp, act)
 
 }
+
+func TestFormatterPrecedence(t *testing.T) {
+	cf := func(record FlatObject, header string) (string, string) {
+		if header == "a" && record["a"] == 1 {
+			return "c(%s)", "1"
+		}
+		return "%s", fmt.Sprintf("%v", record[header])
+	}
+	colFormatter := func(record FlatObject) (string, string) {
+		return "col(%s)", ""
+	}
+	rowFormatter := func(record FlatObject, rowIndex int) string {
+		if rowIndex == 2 {
+			return "row(%s)"
+		}
+		return ""
+	}
+	builder := NewBuilder[FlatObject]().
+		AddHeaders("a", "b").
+		AddCellFormatter(cf).
+		AddColFormatter("a", colFormatter).
+		AddRowFormatter(rowFormatter).
+		AddZebra("z(%s)").
+		AddHighlight(func(record FlatObject) bool { return record["b"] == "x" }, "h(%s)").
+		AddRow(FlatObject{"a": 1, "b": "x"}, FlatObject{"a": 2, "b": "y"}).
+		AddSeparator("-").
+		AddRow(FlatObject{"a": 3, "b": "z"}, FlatObject{"a": 4, "b": "w"})
+
+	act := builder.Build()
+	exp := `
+a	b
+-	-
+c(1)	h(x)
+col(2)	z(y)
+-	-
+col(3)	row(z)
+col(4)	z(w)
+`
+	act = strings.Trim(act, "\n")
+	exp = strings.Trim(exp, "\n")
+	assert.Equal(t, exp, act)
+}
+
+func TestColumnValuePrecedence(t *testing.T) {
+	cf := func(record FlatObject, header string) (string, string) {
+		if record[header] == nil {
+			return "%s", ""
+		}
+		return "%s", fmt.Sprintf("%v", record[header])
+	}
+	colFormatter := func(record FlatObject) (string, string) {
+		return "%s", "col"
+	}
+	builder := NewBuilder[FlatObject]().
+		AddHeaders("a").
+		AddCellFormatter(cf).
+		AddColFormatter("a", colFormatter).
+		AddRow(FlatObject{"a": "cell"}, FlatObject{})
+
+	// cell values win, column values fill the gaps
+	act := builder.Build()
+	exp := `
+a   
+----
+cell
+col 
+`
+	act = strings.Trim(act, "\n")
+	exp = strings.Trim(exp, "\n")
+	assert.Equal(t, exp, act)
+}
+
+func TestSpans(t *testing.T) {
+	cf := func(record FlatObject, header string) (string, string) {
+		return "%s", fmt.Sprintf("%v", record[header])
+	}
+	builder := NewBuilder[FlatObject]().
+		AddHeaders("project", "mon", "tue").
+		AddHeaderGroup("week 42 overview", "mon", "tue").
+		AddCellFormatter(cf).
+		AddTitleRow("internal", "%s").
+		AddRow(FlatObject{"project": "alpha", "mon": "8h", "tue": "7h"}).
+		AddCustomCell("mon", "holiday", "%s", WithSpan(2)).
+		AddFooterCell("project", "total", "%s").
+		AddFooterCell("mon", "15h", "%s", WithFullSpan())
+
+	act := builder.Build()
+	exp := `
+       	week 42 overview
+project	mon	tue     
+-------	---	--------
+internal                
+alpha  	8h 	7h      
+       	holiday         
+-------	----------------
+total  	15h             
+`
+	act = strings.Trim(act, "\n")
+	exp = strings.Trim(exp, "\n")
+	assert.Equal(t, exp, act)
+}
+
+type entry struct {
+	Project string
+	Day     string
+	Hours   int
+}
+
+func TestPivot(t *testing.T) {
+	b := NewBuilder[entry]().AddRow(
+		entry{"alpha", "mon", 2},
+		entry{"beta", "tue", 3},
+		entry{"alpha", "mon", 4},
+		entry{"alpha", "wed", 1},
+	)
+	project := func(e entry) string { return e.Project }
+	day := func(e entry) string { return e.Day }
+	hours := func(e entry) int { return e.Hours }
+
+	act := Pivot(b, project, day, hours, Sum[int], WithRowHeader("project"), WithFill("-")).Build()
+	exp := `
+project	mon	tue	wed
+-------	---	---	---
+alpha  	6  	-  	1  
+beta   	-  	3  	-  
+`
+	act = strings.Trim(act, "\n")
+	exp = strings.Trim(exp, "\n")
+	assert.Equal(t, exp, act)
+
+	// counts regardless of the value type
+	assert.Equal(t, 3, Count([]string{"a", "b", "c"}))
+	act = Pivot(b, project, day, hours, Count[int], WithRowHeader("project"), WithColumns("mon")).Build()
+	assert.Contains(t, act, "alpha  \t2")
+	act = PivotCount(b, project, day, WithFill("0")).Build()
+	exp = `
+key  	mon	tue	wed
+-----	---	---	---
+alpha	2  	0  	1  
+beta 	0  	1  	0  
+`
+	act = strings.Trim(act, "\n")
+	exp = strings.Trim(exp, "\n")
+	assert.Equal(t, exp, act)
+
+	act = Pivot(b, project, day, hours, Max[int], WithColumns("tue", "mon")).Build()
+	exp = `
+key  	tue	mon
+-----	---	---
+alpha	   	4  
+beta 	3  	   
+`
+	act = strings.Trim(act, "\n")
+	exp = strings.Trim(exp, "\n")
+	assert.Equal(t, exp, act)
+
+	// column keys must not hide the row header
+	act = Pivot(b, day, project, hours, Sum[int], WithRowHeader("alpha")).Build()
+	assert.Contains(t, act, "column alpha clashes with row header")
+}
+
+func TestTranspose(t *testing.T) {
+	cf := func(record FlatObject, header string) (string, string) {
+		if header == "hours" {
+			return "<%s>", fmt.Sprintf("%v", record[header])
+		}
+		return "%s", fmt.Sprintf("%v", record[header])
+	}
+	b := NewBuilder[FlatObject]().
+		AddHeaders("name", "hours", "tag").
+		AddCellFormatter(cf).
+		AddRow(FlatObject{"name": "alpha", "hours": 8, "tag": "x"}).
+		AddSeparator("-").
+		AddRow(FlatObject{"name": "beta", "hours": 12, "tag": "y"})
+
+	act := b.Transpose("name").Build()
+	exp := `
+name 	alpha	beta
+-----	-----	----
+hours	<8    >	<12  >
+tag  	x    	y   
+`
+	act = strings.Trim(act, "\n")
+	exp = strings.Trim(exp, "\n")
+	assert.Equal(t, exp, act)
+}
+
+func TestRules(t *testing.T) {
+	cf := func(record FlatObject, header string) (string, string) {
+		if header == "name" && record["name"] == "fixed" {
+			return "fixed(%s)", "fixed"
+		}
+		return "%s", fmt.Sprintf("%v", record[header])
+	}
+	builder := NewBuilder[FlatObject]().
+		AddHeaders("name", "time", "load").
+		AddCellFormatter(cf).
+		AddRules("time", Above(8*time.Hour, "warn(%s)"), Below(0, "fatal(%s)")).
+		AddRules("load", Gradient("low(%s)", "mid(%s)", "high(%s)")).
+		AddRules("name", Matches(regexp.MustCompile("^err"), "red(%s)")).
+		AddRow(
+			FlatObject{"name": "error", "time": 9 * time.Hour, "load": "10%"},
+			FlatObject{"name": "ok", "time": -time.Hour, "load": "55%"},
+			FlatObject{"name": "fixed", "time": time.Hour, "load": "100%"},
+		)
+
+	act := builder.Build()
+	exp := `
+name 	time   	load
+-----	-------	----
+red(error)	warn(9h0m0s )	low(10% )
+ok   	fatal(-1h0m0s)	mid(55% )
+fixed(fixed)	1h0m0s 	high(100%)
+`
+	act = strings.Trim(act, "\n")
+	exp = strings.Trim(exp, "\n")
+	assert.Equal(t, exp, act)
+
+	// non-finite values are not numbers
+	builder = NewBuilder[FlatObject]().
+		AddHeaders("load").
+		AddCellFormatter(cf).
+		AddRules("load", Gradient("low(%s)", "high(%s)")).
+		AddRow(FlatObject{"load": "1"}, FlatObject{"load": "Infinity"}, FlatObject{"load": "NaN"}, FlatObject{"load": "2"})
+	act = builder.Build()
+	exp = `
+load    
+--------
+low(1       )
+Infinity
+NaN     
+high(2       )
+`
+	act = strings.Trim(act, "\n")
+	exp = strings.Trim(exp, "\n")
+	assert.Equal(t, exp, act)
+}
+
+func TestGraphics(t *testing.T) {
+	type day struct {
+		Name  string
+		Hours float64
+		Done  float64
+		Trend []float64
+	}
+	cf := func(record day, header string) (string, string) {
+		return "%s", record.Name
+	}
+	builder := NewBuilder[day]().
+		AddHeaders("day", "hours", "done", "trend").
+		AddCellFormatter(cf).
+		AddBar("hours", func(d day) float64 { return d.Hours }, WithWidth(8)).
+		AddProgress("done", func(d day) float64 { return d.Done }, WithWidth(4)).
+		AddSparkline("trend", func(d day) []float64 { return d.Trend }).
+		AddRow(
+			day{"mon", 8, 1, []float64{1, 2, 3, 4, 5, 6, 7, 8}},
+			day{"tue", 3, 0.5, []float64{8, 1}},
+		)
+
+	act := builder.Build()
+	exp := `
+day	hours   	done     	trend   
+---	--------	---------	--------
+mon	████████	████ 100%	▁▂▃▄▅▆▇█
+tue	███     	██░░  50%	█▁      
+`
+	act = strings.Trim(act, "\n")
+	exp = strings.Trim(exp, "\n")
+	assert.Equal(t, exp, act)
+
+	builder.config.ASCII = true
+	act = builder.Build()
+	exp = `
+day	hours   	done     	trend   
+---	--------	---------	--------
+mon	########	#### 100%	_..--~~^
+tue	###     	##--  50%	^_      
+`
+	act = strings.Trim(act, "\n")
+	exp = strings.Trim(exp, "\n")
+	assert.Equal(t, exp, act)
+
+	// non-finite values leave gaps and keep the bar scale
+	builder = NewBuilder[day]().
+		AddHeaders("day", "hours", "trend").
+		AddCellFormatter(cf).
+		AddBar("hours", func(d day) float64 { return d.Hours }, WithWidth(4)).
+		AddSparkline("trend", func(d day) []float64 { return d.Trend }).
+		AddRow(
+			day{Name: "mon", Hours: 4, Trend: []float64{1, math.NaN(), 3, math.Inf(1)}},
+			day{Name: "tue", Hours: math.NaN(), Trend: []float64{math.NaN()}},
+		)
+	act = builder.Build()
+	exp = `
+day	hours	trend
+---	-----	-----
+mon	█████	▁ █  
+tue	     	     
+`
+	act = strings.Trim(act, "\n")
+	exp = strings.Trim(exp, "\n")
+	assert.Equal(t, exp, act)
+}
+
+func TestTreeRows(t *testing.T) {
+
+	type task struct {
+		name     string
+		duration time.Duration
+		children []task
+		folded   bool
+	}
+	records := []task{
+		{name: "project", children: []task{
+			{name: "design", duration: time.Hour},
+			{name: "build", children: []task{
+				{name: "api", duration: 2 * time.Hour},
+				{name: "ui", duration: 3 * time.Hour},
+			}},
+		}},
+		{name: "admin", folded: true, children: []task{
+			{name: "mail", duration: time.Hour},
+		}},
+	}
+
+	builder := NewBuilder[task]().
+		AddHeaders("name", "duration").
+		AddCellFormatter(func(record task, header string) (string, string) {
+			if header == "name" {
+				return "%s", record.name
+			}
+			return "%s", record.duration.String()
+		}).
+		AddChildren(func(record task) []task { return record.children }).
+		AddCollapsed(func(record task) bool { return record.folded }).
+		AddAggregate("duration", func(record task) float64 { return float64(record.duration) }, Sum[float64], func(value float64) string {
+			return time.Duration(value).String()
+		}).
+		AddRow(records...)
+
+	exp := []string{
+		"project    \t6h0m0s  ",
+		"├── design \t1h0m0s  ",
+		"└── build  \t5h0m0s  ",
+		"    ├── api\t2h0m0s  ",
+		"    └── ui \t3h0m0s  ",
+		"admin [+1] \t1h0m0s  ",
+	}
+	act := strings.Split(strings.Trim(builder.Build(), "\n"), "\n")
+	assert.Equal(t, exp, act[2:])
+
+	// ascii guides
+	ascii := NewBuilder[task](func(c *Config) { c.ASCII = true }).
+		AddHeaders("name").
+		AddCellFormatter(func(record task, header string) (string, string) { return "%s", record.name }).
+		AddChildren(func(record task) []task { return record.children }).
+		AddRow(records[0])
+	assert.Contains(t, ascii.Build(), "    `-- ui")
+
+	// graphics in the tree column keep their guides
+	bars := NewBuilder[task](func(c *Config) { c.ASCII = true }).
+		AddHeaders("duration").
+		AddCellFormatter(func(record task, header string) (string, string) { return "%s", "" }).
+		AddBar("duration", func(record task) float64 { return record.duration.Hours() }, WithWidth(4), WithScale(4)).
+		AddChildren(func(record task) []task { return record.children }).
+		AddRow(records[0])
+	exp = []string{
+		"            ",
+		"|-- ##      ",
+		"`--         ",
+		"    |-- ##  ",
+		"    `-- ### ",
+	}
+	act = strings.Split(strings.Trim(bars.Build(), "\n"), "\n")
+	assert.Equal(t, exp, act[2:])
+}
+
+func TestDetail(t *testing.T) {
+
+	type address struct {
+		City string `json:"city"`
+		Zip  string `json:"zip"`
+	}
+	type person struct {
+		Name    string   `json:"name"`
+		Age     int      `json:"age"`
+		Address address  `json:"address"`
+		Tags    []string `json:"tags"`
+		Note    string   `json:"note"`
+	}
+	record := person{Name: "Alice", Age: 30, Address: address{City: "Berlin", Zip: "10115"}, Tags: []string{"a", "b"}, Note: "first\nsecond"}
+
+	exp := strings.Join([]string{
+		"age:      30",
+		"name:     Alice",
+		"address:",
+		"  city:  Berlin",
+		"tags:     a, b",
+		"note:     first",
+		"          second",
+	}, "\n")
+	assert.Equal(t, exp, Detail(record, WithFields("age"), WithOmit("address.zip")))
+
+	// list of flat objects
+	records := []FlatObject{{"a": 1, "bb": "x"}, {"a": 2, "bb": nil}}
+	exp = strings.Join([]string{
+		"a:   1",
+		"bb:  x",
+		"======",
+		"a:  2",
+	}, "\n")
+	assert.Equal(t, exp, DetailList(records, WithOmitEmpty(), WithDivider("=")))
+
+	// no object
+	assert.Equal(t, "error: expected object, got int", Detail(3))
+}
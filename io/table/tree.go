@@ -0,0 +1,117 @@
+package table
+
+import "github.com/rollicks-c/term/internal/text"
+
+type treeConfig[T any] struct {
+	children   func(record T) []T
+	collapsed  func(record T) bool
+	aggregates map[string]aggregate[T]
+}
+
+type aggregate[T any] struct {
+	value  func(record T) float64
+	agg    Aggregator[float64]
+	format func(value float64) string
+}
+
+func (b *Builder[T]) AddChildren(children func(record T) []T) *Builder[T] {
+	b.tree.children = children
+	return b
+}
+
+func (b *Builder[T]) AddCollapsed(collapsed func(record T) bool) *Builder[T] {
+	b.tree.collapsed = collapsed
+	return b
+}
+
+func (b *Builder[T]) AddAggregate(header string, value func(record T) float64, agg Aggregator[float64], format func(value float64) string) *Builder[T] {
+	if b.tree.aggregates == nil {
+		b.tree.aggregates = make(map[string]aggregate[T])
+	}
+	b.tree.aggregates[header] = aggregate[T]{
+		value:  value,
+		agg:    agg,
+		format: format,
+	}
+	return b
+}
+
+func (b *Builder[T]) expandRows() []row[T] {
+
+	// flat table
+	if b.tree.children == nil {
+		return b.rows
+	}
+
+	// expand data rows into their subtrees
+	guides := text.Guides(b.config.ASCII)
+	layout := make([]row[T], 0, len(b.rows))
+	for _, r := range b.rows {
+		dr, ok := r.(dataRow[T])
+		if !ok {
+			layout = append(layout, r)
+			continue
+		}
+		layout = b.expandNode(layout, dr.record, "", "", guides)
+	}
+
+	return layout
+}
+
+func (b *Builder[T]) expandNode(layout []row[T], record T, guide, prefix string, guides text.TreeGuides) []row[T] {
+
+	// node itself
+	children := b.tree.children(record)
+	collapsed := b.tree.collapsed != nil && b.tree.collapsed(record)
+	dr := dataRow[T]{
+		record:     record,
+		guide:      guide,
+		aggregates: b.aggregateNode(children),
+	}
+	if collapsed {
+		dr.hidden = len(children)
+	}
+	layout = append(layout, dr)
+
+	// children
+	if collapsed {
+		return layout
+	}
+	for i, child := range children {
+		childGuide, childPrefix := guides.Branch, guides.Pipe
+		if i == len(children)-1 {
+			childGuide, childPrefix = guides.Last, guides.Space
+		}
+		layout = b.expandNode(layout, child, prefix+childGuide, prefix+childPrefix, guides)
+	}
+
+	return layout
+}
+
+func (b *Builder[T]) aggregateNode(children []T) map[string]string {
+
+	// leaves keep their own values
+	if len(children) == 0 || len(b.tree.aggregates) == 0 {
+		return nil
+	}
+
+	// aggregate subtree
+	aggregates := make(map[string]string)
+	for header, a := range b.tree.aggregates {
+		aggregates[header] = a.format(b.aggregateValue(a, children))
+	}
+	return aggregates
+}
+
+func (b *Builder[T]) aggregateValue(a aggregate[T], children []T) float64 {
+	values := make([]float64, len(children))
+	for i, child := range children {
+		grandChildren := b.tree.children(child)
+		if len(grandChildren) == 0 {
+			values[i] = a.value(child)
+			continue
+		}
+		values[i] = b.aggregateValue(a, grandChildren)
+	}
+	return a.agg(values)
+}
@@ -0,0 +1,602 @@
+package table
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"github.com/rollicks-c/term/internal/text"
+	"github.com/rollicks-c/term/internal/tty"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrCanceled = errors.New("canceled")
+
+const (
+	viewGap       = "  "
+	viewChrome    = 3
+	reverseStyle  = "\033[7m%s\033[27m"
+	underlineOn   = "\033[4m"
+	underlineOff  = "\033[24m"
+	viewHelp      = "enter: select  /: search  s: sort  v: details  q: quit"
+	viewHelpMulti = "space: toggle  a: all  enter: confirm  /: search  s: sort  v: details  q: quit"
+)
+
+type ViewOption func(config *viewConfig)
+
+type viewConfig struct {
+	multiSelect bool
+	title       string
+}
+
+type viewCell struct {
+	value string
+	style string
+}
+
+type viewer[T any] struct {
+	config     viewConfig
+	headers    []string
+	widths     []int
+	records    []T
+	cells      [][]viewCell
+	order      []int
+	cursor     int
+	offset     int
+	column     int
+	colOffset  int
+	sortColumn int
+	sortDesc   bool
+	query      string
+	searching  bool
+	selected   map[int]bool
+	detail     bool
+	width      int
+	height     int
+	status     string
+}
+
+func WithMultiSelect() ViewOption {
+	return func(config *viewConfig) {
+		config.multiSelect = true
+	}
+}
+
+func WithTitle(title string) ViewOption {
+	return func(config *viewConfig) {
+		config.title = title
+	}
+}
+
+func (b *Builder[T]) Browse(in io.Reader, out io.Writer, options ...ViewOption) ([]T, error) {
+
+	// nothing to choose from
+	v := newViewer(b, options...)
+	if len(v.records) == 0 {
+		return nil, fmt.Errorf("no records to choose from")
+	}
+
+	// enter full screen
+	term, err := tty.Open(in, out)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = term.Close()
+	}()
+
+	// interact
+	for {
+		v.width, v.height = term.Size()
+		v.scroll()
+		if err := term.Draw(v.view()); err != nil {
+			return nil, err
+		}
+		key, err := term.ReadKey()
+		if err != nil {
+			return nil, err
+		}
+		done, canceled := v.handle(key)
+		if canceled {
+			return nil, ErrCanceled
+		}
+		if done {
+			return v.result(), nil
+		}
+	}
+}
+
+func (b *Builder[T]) Pick(in io.Reader, out io.Writer, options ...ViewOption) (T, error) {
+	var none T
+	selection, err := b.Browse(in, out, options...)
+	if err != nil {
+		return none, err
+	}
+	return selection[0], nil
+}
+
+func newViewer[T any](b *Builder[T], options ...ViewOption) *viewer[T] {
+
+	// render cells
+	b.createCells()
+	v := &viewer[T]{
+		headers:    b.headers,
+		widths:     b.getMaxWidths(),
+		sortColumn: -1,
+		selected:   make(map[int]bool),
+		width:      80,
+		height:     24,
+	}
+	for _, opt := range options {
+		opt(&v.config)
+	}
+
+	// collect data rows
+	for rowIndex, r := range b.layout {
+		dr, ok := r.(dataRow[T])
+		if !ok {
+			continue
+		}
+		cells := make([]viewCell, len(b.headers))
+		for colIndex, cell := range b.cells[rowIndex] {
+			cells[colIndex] = toViewCell(cell)
+		}
+		v.order = append(v.order, len(v.records))
+		v.records = append(v.records, dr.record)
+		v.cells = append(v.cells, cells)
+	}
+
+	return v
+}
+
+func toViewCell(cell Cell) viewCell {
+	if dc, ok := cell.(dataCell); ok {
+		return viewCell{value: dc.prefix + dc.value, style: dc.style}
+	}
+	return viewCell{value: strings.TrimSpace(cell.Render(cell.Len())), style: "%s"}
+}
+
+func (v *viewer[T]) handle(key tty.Key) (bool, bool) {
+
+	// search input
+	if v.searching {
+		switch key.Code {
+		case tty.KeyEnter:
+			v.searching = false
+		case tty.KeyEsc, tty.KeyCtrlC:
+			v.searching = false
+			v.query = ""
+		case tty.KeyBackspace:
+			if len(v.query) > 0 {
+				runes := []rune(v.query)
+				v.query = string(runes[:len(runes)-1])
+			}
+			v.find(v.cursor, 1)
+		case tty.KeyRune:
+			v.query += string(key.Rune)
+			v.find(v.cursor, 1)
+		}
+		return false, false
+	}
+
+	// detail view
+	v.status = ""
+	if v.detail {
+		switch {
+		case key.Code == tty.KeyCtrlC:
+			return false, true
+		case key.Code == tty.KeyEsc, key.Code == tty.KeyTab, key.Rune == 'v', key.Rune == 'q':
+			v.detail = false
+		case key.Code == tty.KeyDown, key.Rune == 'j':
+			v.move(1)
+		case key.Code == tty.KeyUp, key.Rune == 'k':
+			v.move(-1)
+		case key.Code == tty.KeyEnter:
+			v.confirm()
+			return true, false
+		}
+		return false, false
+	}
+
+	// table view
+	switch key.Code {
+	case tty.KeyCtrlC, tty.KeyEsc:
+		return false, true
+	case tty.KeyEnter:
+		v.confirm()
+		return true, false
+	case tty.KeyTab:
+		v.detail = true
+	case tty.KeyDown:
+		v.move(1)
+	case tty.KeyUp:
+		v.move(-1)
+	case tty.KeyPgDn:
+		v.move(v.pageSize())
+	case tty.KeyPgUp:
+		v.move(-v.pageSize())
+	case tty.KeyHome:
+		v.move(-len(v.order))
+	case tty.KeyEnd:
+		v.move(len(v.order))
+	case tty.KeyLeft:
+		v.focus(-1)
+	case tty.KeyRight:
+		v.focus(1)
+	case tty.KeyRune:
+		switch key.Rune {
+		case 'q':
+			return false, true
+		case 'j':
+			v.move(1)
+		case 'k':
+			v.move(-1)
+		case 'g':
+			v.move(-len(v.order))
+		case 'G':
+			v.move(len(v.order))
+		case 'h':
+			v.focus(-1)
+		case 'l':
+			v.focus(1)
+		case 's':
+			v.sort()
+		case 'v':
+			v.detail = true
+		case '/':
+			v.searching = true
+			v.query = ""
+		case 'n':
+			v.find(v.cursor+1, 1)
+		case 'N':
+			v.find(v.cursor-1, -1)
+		case ' ':
+			v.toggle()
+		case 'a':
+			v.toggleAll()
+		}
+	}
+
+	return false, false
+}
+
+func (v *viewer[T]) confirm() {
+	if v.config.multiSelect && len(v.selected) == 0 {
+		v.selected[v.order[v.cursor]] = true
+	}
+}
+
+func (v *viewer[T]) result() []T {
+
+	// single selection
+	if !v.config.multiSelect {
+		return []T{v.records[v.order[v.cursor]]}
+	}
+
+	// selected records in display order
+	result := make([]T, 0, len(v.selected))
+	for _, index := range v.order {
+		if v.selected[index] {
+			result = append(result, v.records[index])
+		}
+	}
+	return result
+}
+
+func (v *viewer[T]) move(delta int) {
+	v.cursor += delta
+	if v.cursor >= len(v.order) {
+		v.cursor = len(v.order) - 1
+	}
+	if v.cursor < 0 {
+		v.cursor = 0
+	}
+}
+
+func (v *viewer[T]) focus(delta int) {
+	v.column += delta
+	if v.column >= len(v.headers) {
+		v.column = len(v.headers) - 1
+	}
+	if v.column < 0 {
+		v.column = 0
+	}
+}
+
+func (v *viewer[T]) toggle() {
+	if !v.config.multiSelect {
+		return
+	}
+	index := v.order[v.cursor]
+	if v.selected[index] {
+		delete(v.selected, index)
+	} else {
+		v.selected[index] = true
+	}
+	v.move(1)
+}
+
+func (v *viewer[T]) toggleAll() {
+	if !v.config.multiSelect {
+		return
+	}
+	if len(v.selected) == len(v.records) {
+		v.selected = make(map[int]bool)
+		return
+	}
+	for index := range v.records {
+		v.selected[index] = true
+	}
+}
+
+func (v *viewer[T]) sort() {
+
+	// toggle direction on same column
+	if v.sortColumn == v.column {
+		v.sortDesc = !v.sortDesc
+	} else {
+		v.sortColumn = v.column
+		v.sortDesc = false
+	}
+
+	// sort, keeping cursor on its record
+	current := v.order[v.cursor]
+	sort.SliceStable(v.order, func(i, j int) bool {
+		a := v.cells[v.order[i]][v.sortColumn].value
+		b := v.cells[v.order[j]][v.sortColumn].value
+		if v.sortDesc {
+			return compareValues(b, a) < 0
+		}
+		return compareValues(a, b) < 0
+	})
+	for pos, index := range v.order {
+		if index == current {
+			v.cursor = pos
+		}
+	}
+}
+
+func (v *viewer[T]) find(from, direction int) {
+
+	// nothing to search
+	if v.query == "" {
+		return
+	}
+
+	// walk rows in direction
+	for pos := from; pos >= 0 && pos < len(v.order); pos += direction {
+		if v.matches(v.order[pos]) {
+			v.cursor = pos
+			return
+		}
+	}
+	v.status = fmt.Sprintf("pattern not found: %s", v.query)
+}
+
+func (v *viewer[T]) matches(index int) bool {
+	query := strings.ToLower(v.query)
+	for _, cell := range v.cells[index] {
+		if strings.Contains(strings.ToLower(cell.value), query) {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *viewer[T]) pageSize() int {
+	size := v.height - viewChrome
+	if v.config.title != "" {
+		size--
+	}
+	if size < 1 {
+		return 1
+	}
+	return size
+}
+
+func (v *viewer[T]) scroll() {
+
+	// keep cursor row visible
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	}
+	if v.cursor >= v.offset+v.pageSize() {
+		v.offset = v.cursor - v.pageSize() + 1
+	}
+
+	// keep focused column visible
+	if v.column < v.colOffset {
+		v.colOffset = v.column
+	}
+	for v.colOffset < v.column && v.lastVisibleColumn() < v.column {
+		v.colOffset++
+	}
+}
+
+func (v *viewer[T]) lastVisibleColumn() int {
+	used := len(viewGap)
+	for colIndex := v.colOffset; colIndex < len(v.headers); colIndex++ {
+		used += v.widths[colIndex] + len(viewGap)
+		if used > v.width {
+			return colIndex - 1
+		}
+	}
+	return len(v.headers) - 1
+}
+
+func (v *viewer[T]) view() []string {
+
+	// detail view
+	if v.detail {
+		return v.viewDetail()
+	}
+
+	lines := make([]string, 0, v.height)
+	if v.config.title != "" {
+		lines = append(lines, text.Truncate(v.config.title, v.width))
+	}
+
+	// headers
+	header, separator := viewGap, viewGap
+	for colIndex := v.colOffset; colIndex < len(v.headers); colIndex++ {
+		name := text.Pad(v.headers[colIndex], v.widths[colIndex])
+		if colIndex == v.column {
+			name = underlineOn + name + underlineOff
+		}
+		header += name + viewGap
+		separator += strings.Repeat("-", v.widths[colIndex]) + viewGap
+	}
+	lines = append(lines, text.Truncate(header, v.width), text.Truncate(separator, v.width))
+
+	// rows
+	for pos := v.offset; pos < v.offset+v.pageSize() && pos < len(v.order); pos++ {
+		lines = append(lines, text.Truncate(v.viewRow(pos), v.width))
+	}
+	for len(lines) < v.height-1 {
+		lines = append(lines, "")
+	}
+
+	// status
+	lines = append(lines, text.Truncate(v.viewStatus(), v.width))
+
+	return lines
+}
+
+func (v *viewer[T]) viewRow(pos int) string {
+	index := v.order[pos]
+
+	// marker
+	marker := viewGap
+	if v.selected[index] {
+		marker = "* "
+	}
+	if pos == v.cursor {
+		marker = "> "
+		if v.selected[index] {
+			marker = ">*"
+		}
+	}
+
+	// cells
+	row := ""
+	for colIndex := v.colOffset; colIndex < len(v.headers); colIndex++ {
+		cell := v.cells[index][colIndex]
+		value := text.Pad(cell.value, v.widths[colIndex])
+		if pos == v.cursor {
+			row += value + viewGap
+			continue
+		}
+		if v.query != "" {
+			value = highlight(value, v.query)
+		}
+		row += fmt.Sprintf(cell.style, value) + viewGap
+	}
+
+	// cursor row
+	if pos == v.cursor {
+		return marker + fmt.Sprintf(reverseStyle, row)
+	}
+	return marker + row
+}
+
+func (v *viewer[T]) viewStatus() string {
+
+	// search input
+	if v.searching {
+		return "/" + v.query
+	}
+	if v.status != "" {
+		return v.status
+	}
+
+	// position, sorting and selection
+	parts := []string{fmt.Sprintf("%d/%d", v.cursor+1, len(v.order))}
+	if v.sortColumn >= 0 {
+		direction := "asc"
+		if v.sortDesc {
+			direction = "desc"
+		}
+		parts = append(parts, fmt.Sprintf("sort: %s %s", v.headers[v.sortColumn], direction))
+	}
+	if v.query != "" {
+		parts = append(parts, fmt.Sprintf("search: %s", v.query))
+	}
+	if v.config.multiSelect {
+		parts = append(parts, fmt.Sprintf("%d selected", len(v.selected)))
+		parts = append(parts, viewHelpMulti)
+	} else {
+		parts = append(parts, viewHelp)
+	}
+
+	return fmt.Sprintf(reverseStyle, strings.Join(parts, " | "))
+}
+
+func (v *viewer[T]) viewDetail() []string {
+	index := v.order[v.cursor]
+
+	// key width
+	keyWidth := 0
+	for _, header := range v.headers {
+		if text.Width(header) > keyWidth {
+			keyWidth = text.Width(header)
+		}
+	}
+
+	// one field per line
+	lines := []string{fmt.Sprintf("record %d/%d", v.cursor+1, len(v.order)), ""}
+	for colIndex, header := range v.headers {
+		cell := v.cells[index][colIndex]
+		line := fmt.Sprintf("%s  %s", text.Pad(header, keyWidth), fmt.Sprintf(cell.style, cell.value))
+		lines = append(lines, text.Truncate(line, v.width))
+	}
+	for len(lines) < v.height-1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, fmt.Sprintf(reverseStyle, "j/k: next/previous  enter: select  v: back"))
+
+	return lines
+}
+
+func highlight(value, query string) string {
+	lower := strings.ToLower(value)
+	query = strings.ToLower(query)
+	if len(lower) != len(value) {
+		return value
+	}
+	out := strings.Builder{}
+	for {
+		i := strings.Index(lower, query)
+		if i < 0 {
+			out.WriteString(value)
+			break
+		}
+		out.WriteString(value[:i])
+		out.WriteString(fmt.Sprintf(reverseStyle, value[i:i+len(query)]))
+		value = value[i+len(query):]
+		lower = lower[i+len(query):]
+	}
+	return out.String()
+}
+
+func compareValues(a, b string) int {
+
+	// numbers
+	if x, err := strconv.ParseFloat(a, 64); err == nil {
+		if y, err := strconv.ParseFloat(b, 64); err == nil {
+			return cmp.Compare(x, y)
+		}
+	}
+
+	// durations
+	if x, err := time.ParseDuration(a); err == nil {
+		if y, err := time.ParseDuration(b); err == nil {
+			return cmp.Compare(x, y)
+		}
+	}
+
+	// text
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
@@ -0,0 +1,91 @@
+package table
+
+import (
+	"fmt"
+	"github.com/rollicks-c/term/internal/tty"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type viewRecord struct {
+	Name  string
+	Hours int
+}
+
+func createViewer(options ...ViewOption) *viewer[viewRecord] {
+	cf := func(record viewRecord, header string) (string, string) {
+		if header == "name" {
+			return "%s", record.Name
+		}
+		return "%s", fmt.Sprintf("%d", record.Hours)
+	}
+	b := NewBuilder[viewRecord]().
+		AddHeaders("name", "hours").
+		AddCellFormatter(cf).
+		AddRow(viewRecord{"beta", 10}, viewRecord{"alpha", 9}).
+		AddSeparator("-").
+		AddRow(viewRecord{"gamma", 100})
+	return newViewer(b, options...)
+}
+
+func press(v *viewer[viewRecord], input string) (bool, bool) {
+	var done, canceled bool
+	for _, key := range tty.Decode([]byte(input)) {
+		done, canceled = v.handle(key)
+	}
+	return done, canceled
+}
+
+func TestViewerSkipsNonDataRows(t *testing.T) {
+	v := createViewer()
+	assert.Len(t, v.records, 3)
+	assert.Equal(t, []int{0, 1, 2}, v.order)
+}
+
+func TestViewerSort(t *testing.T) {
+	v := createViewer()
+
+	press(v, "s")
+	assert.Equal(t, []int{1, 0, 2}, v.order)
+	assert.Equal(t, 1, v.cursor)
+
+	press(v, "ls")
+	assert.Equal(t, []int{1, 0, 2}, v.order)
+
+	press(v, "s")
+	assert.Equal(t, []int{2, 0, 1}, v.order)
+
+	done, canceled := press(v, "\r")
+	assert.True(t, done)
+	assert.False(t, canceled)
+	assert.Equal(t, []viewRecord{{"beta", 10}}, v.result())
+}
+
+func TestViewerSearch(t *testing.T) {
+	v := createViewer()
+
+	press(v, "/amm")
+	assert.Equal(t, 2, v.cursor)
+	assert.True(t, v.searching)
+
+	press(v, "\rN")
+	assert.Equal(t, 2, v.cursor)
+	assert.Equal(t, "pattern not found: amm", v.status)
+
+	assert.Contains(t, v.viewRow(0), "beta")
+	assert.Contains(t, highlight("gamma", "AMM"), "g\033[7mamm\033[27ma")
+}
+
+func TestViewerMultiSelect(t *testing.T) {
+	v := createViewer(WithMultiSelect())
+
+	press(v, " j ")
+	assert.Len(t, v.selected, 2)
+
+	press(v, "gs\r")
+	assert.Equal(t, []viewRecord{{"beta", 10}, {"gamma", 100}}, v.result())
+
+	v = createViewer(WithMultiSelect())
+	_, canceled := press(v, "q")
+	assert.True(t, canceled)
+}
@@ -0,0 +1,109 @@
+package tree
+
+import (
+	"fmt"
+	"sort"
+)
+
+type Node interface {
+	Label() string
+	Children() []Node
+}
+
+type Annotated interface {
+	Annotation() string
+}
+
+type Styled interface {
+	Style() string
+}
+
+type Collapsible interface {
+	Collapsed() bool
+}
+
+type Item struct {
+	label      string
+	annotation string
+	style      string
+	collapsed  bool
+	children   []Node
+}
+
+func New(label string) *Item {
+	return &Item{
+		label:    label,
+		style:    "%s",
+		children: []Node{},
+	}
+}
+
+func (i *Item) Add(children ...Node) *Item {
+	i.children = append(i.children, children...)
+	return i
+}
+
+func (i *Item) AddItem(label string) *Item {
+	child := New(label)
+	i.children = append(i.children, child)
+	return child
+}
+
+func (i *Item) SetAnnotation(annotation string) *Item {
+	i.annotation = annotation
+	return i
+}
+
+func (i *Item) SetStyle(style string) *Item {
+	i.style = style
+	return i
+}
+
+func (i *Item) Collapse() *Item {
+	i.collapsed = true
+	return i
+}
+
+func (i *Item) Label() string {
+	return i.label
+}
+
+func (i *Item) Children() []Node {
+	return i.children
+}
+
+func (i *Item) Annotation() string {
+	return i.annotation
+}
+
+func (i *Item) Style() string {
+	return i.style
+}
+
+func (i *Item) Collapsed() bool {
+	return i.collapsed
+}
+
+func FromMap(label string, data any) Node {
+	item := New(label)
+	switch value := data.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			item.Add(FromMap(k, value[k]))
+		}
+	case []any:
+		for i, v := range value {
+			item.Add(FromMap(fmt.Sprintf("[%d]", i), v))
+		}
+	case nil:
+		item.SetAnnotation("null")
+	default:
+		item.SetAnnotation(fmt.Sprintf("%v", value))
+	}
+	return item
+}
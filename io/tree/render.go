@@ -0,0 +1,144 @@
+package tree
+
+import (
+	"fmt"
+	"github.com/rollicks-c/term/internal/text"
+	"strings"
+)
+
+type Option func(config *Config)
+
+type Config struct {
+	MaxDepth  int
+	ASCII     bool
+	HideRoot  bool
+	Indention string
+}
+
+type line struct {
+	prefix     string
+	label      string
+	annotation string
+}
+
+func WithDepth(depth int) Option {
+	return func(config *Config) {
+		config.MaxDepth = depth
+	}
+}
+
+func WithASCII(state bool) Option {
+	return func(config *Config) {
+		config.ASCII = state
+	}
+}
+
+func WithHideRoot(state bool) Option {
+	return func(config *Config) {
+		config.HideRoot = state
+	}
+}
+
+func WithIndention(chars string) Option {
+	return func(config *Config) {
+		config.Indention = chars
+	}
+}
+
+func Render(root Node, options ...Option) string {
+
+	// init config
+	config := &Config{}
+	for _, opt := range options {
+		opt(config)
+	}
+	g := text.Guides(config.ASCII)
+
+	// collect lines
+	lines := make([]line, 0)
+	if config.HideRoot {
+		children := root.Children()
+		for i, child := range children {
+			lines = collect(lines, child, "", i == len(children)-1, 1, config, g)
+		}
+	} else {
+		lines = append(lines, newLine("", root, 0, config))
+		lines = collectChildren(lines, root, "", 0, config, g)
+	}
+
+	// align annotations
+	width := 0
+	for _, l := range lines {
+		width = max(width, text.Width(l.prefix+l.label))
+	}
+	out := ""
+	for _, l := range lines {
+		content := l.prefix + l.label
+		if l.annotation != "" {
+			content = text.Pad(content, width) + "  " + l.annotation
+		}
+		out += config.Indention + content + "\n"
+	}
+
+	return out
+}
+
+func collect(lines []line, node Node, prefix string, last bool, depth int, config *Config, g text.TreeGuides) []line {
+
+	// node itself
+	guide, childPrefix := g.Branch, prefix+g.Pipe
+	if last {
+		guide, childPrefix = g.Last, prefix+g.Space
+	}
+	lines = append(lines, newLine(prefix+guide, node, depth, config))
+
+	// children
+	return collectChildren(lines, node, childPrefix, depth, config, g)
+}
+
+func collectChildren(lines []line, node Node, prefix string, depth int, config *Config, g text.TreeGuides) []line {
+	if !expanded(node, depth, config) {
+		return lines
+	}
+	children := node.Children()
+	for i, child := range children {
+		lines = collect(lines, child, prefix, i == len(children)-1, depth+1, config, g)
+	}
+	return lines
+}
+
+func newLine(prefix string, node Node, depth int, config *Config) line {
+
+	// label
+	label := node.Label()
+	if styled, ok := node.(Styled); ok && styled.Style() != "" {
+		label = fmt.Sprintf(styled.Style(), label)
+	}
+
+	// hidden children
+	if count := len(node.Children()); count > 0 && !expanded(node, depth, config) {
+		label += fmt.Sprintf(" [+%d]", count)
+	}
+
+	// annotation
+	annotation := ""
+	if annotated, ok := node.(Annotated); ok {
+		annotation = strings.ReplaceAll(annotated.Annotation(), "\n", "\\n")
+	}
+
+	return line{
+		prefix:     prefix,
+		label:      label,
+		annotation: annotation,
+	}
+}
+
+func expanded(node Node, depth int, config *Config) bool {
+	if config.MaxDepth > 0 && depth >= config.MaxDepth {
+		return false
+	}
+	if collapsible, ok := node.(Collapsible); ok && collapsible.Collapsed() {
+		return false
+	}
+	return true
+}
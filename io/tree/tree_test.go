@@ -0,0 +1,60 @@
+package tree
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func createTree() Node {
+	root := New("project")
+	api := root.AddItem("api").SetAnnotation("12h")
+	api.AddItem("auth").SetAnnotation("4h")
+	api.AddItem("billing").SetAnnotation("8h")
+	root.AddItem("docs").SetStyle("<%s>").Collapse().AddItem("intro")
+	root.AddItem("release")
+	return root
+}
+
+func TestRender(t *testing.T) {
+	act := Render(createTree())
+	exp := `
+project
+├── api          12h
+│   ├── auth     4h
+│   └── billing  8h
+├── <docs> [+1]
+└── release
+`
+	assert.Equal(t, strings.TrimLeft(exp, "\n"), act)
+}
+
+func TestRenderDepthAndASCII(t *testing.T) {
+	act := Render(createTree(), WithDepth(1), WithASCII(true), WithIndention("  "))
+	exp := `
+  project
+  |-- api [+2]     12h
+  |-- <docs> [+1]
+  ` + "`" + `-- release
+`
+	assert.Equal(t, strings.TrimLeft(exp, "\n"), act)
+}
+
+func TestFromMap(t *testing.T) {
+	data := map[string]any{}
+	raw := `{"name": "term", "deps": ["promptui", "testify"], "meta": {"stars": 3, "license": null}}`
+	assert.NoError(t, json.Unmarshal([]byte(raw), &data))
+
+	act := Render(FromMap("module", data), WithHideRoot(true))
+	exp := `
+├── deps
+│   ├── [0]      promptui
+│   └── [1]      testify
+├── meta
+│   ├── license  null
+│   └── stars    3
+└── name         term
+`
+	assert.Equal(t, strings.TrimLeft(exp, "\n"), act)
+}
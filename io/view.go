@@ -0,0 +1,11 @@
+package io
+
+import "github.com/rollicks-c/term/io/table"
+
+func Browse[T any](m *Module, b *table.Builder[T], options ...table.ViewOption) ([]T, error) {
+	return b.Browse(m.in, m.out, options...)
+}
+
+func Pick[T any](m *Module, b *table.Builder[T], options ...table.ViewOption) (T, error) {
+	return b.Pick(m.in, m.out, options...)
+}
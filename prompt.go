@@ -2,25 +2,14 @@ package term
 
 import (
 	"fmt"
-	"github.com/manifoldco/promptui"
+	"github.com/rollicks-c/term/args"
+	"github.com/rollicks-c/term/io"
 	"strconv"
-	"strings"
 )
 
 func PromptSecret(prompt string) (string, error) {
 
-	dataPrompt := promptui.Prompt{
-		Label:   prompt,
-		Default: "",
-		Mask:    '*',
-		Validate: func(s string) error {
-			if strings.TrimSpace(s) == "" {
-				return fmt.Errorf("value required")
-			}
-			return nil
-		},
-	}
-	value, err := dataPrompt.Run()
+	value, err := IO().PromptPassword(prompt, io.WithRequired())
 	if err != nil {
 		return "", err
 	}
@@ -28,19 +17,16 @@ func PromptSecret(prompt string) (string, error) {
 
 }
 
+func Confirm(prompt string) (bool, error) {
+	return IO().ConfirmE(prompt)
+}
+
 func PromptString(prompt, defaultValue string) (string, error) {
 
-	dataPrompt := promptui.Prompt{
-		Label:   fmt.Sprintf("%s (%s)", prompt, defaultValue),
-		Default: defaultValue,
-		Validate: func(s string) error {
-			if strings.TrimSpace(s) == "" {
-				return fmt.Errorf("value required")
-			}
-			return nil
-		},
-	}
-	value, err := dataPrompt.Run()
+	value, err := IO().PromptString(prompt,
+		io.WithDefault(defaultValue),
+		io.WithRequired(),
+	)
 	if err != nil {
 		return "", err
 	}
@@ -50,11 +36,9 @@ func PromptString(prompt, defaultValue string) (string, error) {
 
 func PromptStringOptional(prompt, defaultValue string) (string, error) {
 
-	dataPrompt := promptui.Prompt{
-		Label:   fmt.Sprintf("%s (%s)", prompt, defaultValue),
-		Default: defaultValue,
-	}
-	value, err := dataPrompt.Run()
+	value, err := IO().PromptString(prompt,
+		io.WithDefault(defaultValue),
+	)
 	if err != nil {
 		return "", err
 	}
@@ -64,21 +48,15 @@ func PromptStringOptional(prompt, defaultValue string) (string, error) {
 
 func PromptInt(prompt string, defaultValue int) (int, error) {
 
-	dataPrompt := promptui.Prompt{
-		Label:   fmt.Sprintf("%s (%d)", prompt, defaultValue),
-		Default: fmt.Sprintf("%d", defaultValue),
-		Validate: func(s string) error {
+	value, err := IO().PromptInt(prompt,
+		io.WithDefault(fmt.Sprintf("%d", defaultValue)),
+		io.WithValidation(func(s string) error {
 			if _, err := strconv.Atoi(s); err != nil {
 				return err
 			}
 			return nil
-		},
-	}
-	valueRaw, err := dataPrompt.Run()
-	if err != nil {
-		return defaultValue, err
-	}
-	value, err := strconv.Atoi(valueRaw)
+		}),
+	)
 	if err != nil {
 		return defaultValue, err
 	}
@@ -88,24 +66,34 @@ func PromptInt(prompt string, defaultValue int) (int, error) {
 
 func PromptFloat(prompt string, defaultValue float64) (float64, error) {
 
-	dataPrompt := promptui.Prompt{
-		Label:   fmt.Sprintf("%s (%f)", prompt, defaultValue),
-		Default: fmt.Sprintf("%f", defaultValue),
-		Validate: func(s string) error {
+	value, err := IO().PromptFloat(prompt,
+		io.WithDefault(fmt.Sprintf("%f", defaultValue)),
+		io.WithValidation(func(s string) error {
 			if _, err := strconv.ParseFloat(s, 64); err != nil {
 				return err
 			}
 			return nil
-		},
-	}
-	valueRaw, err := dataPrompt.Run()
-	if err != nil {
-		return defaultValue, err
-	}
-	value, err := strconv.ParseFloat(valueRaw, 64)
+		}),
+	)
 	if err != nil {
 		return defaultValue, err
 	}
 	return value, nil
 
 }
+
+func PromptChoice[T any](prompt string, choices []io.Choice[T]) (T, error) {
+	return io.Choose(IO(), prompt, choices)
+}
+
+func PromptMulti[T any](prompt string, choices []io.Choice[T]) ([]T, error) {
+	return io.MultiSelect(IO(), prompt, choices)
+}
+
+func PromptForm(target any, options ...io.FormOption) error {
+	return IO().Form(target, options...)
+}
+
+func FindItem(label string, provider args.ListProvider, options ...io.FinderOption) (any, error) {
+	return args.FindItem(IO(), label, provider, options...)
+}
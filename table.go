@@ -10,6 +10,7 @@ type RowFormatter func(row map[string]string, rowIndex int) string
 
 type ColFormatter func(value string, rowIndex int) (string, string)
 
+// Deprecated: use table.Builder, which supports the same formatters via AddColFormatter and AddRowFormatter.
 type TableViewBuilder struct {
 	cellFormatter CellFormatter
 	rowFormatter  RowFormatter
@@ -18,6 +19,7 @@ type TableViewBuilder struct {
 	rows          [][]string
 }
 
+// Deprecated: use TableEx.
 func TableView() *TableViewBuilder {
 	return &TableViewBuilder{
 		headers: []string{},
@@ -16,6 +16,12 @@ func WithIndention(chars string) table.Option {
 	}
 }
 
+func WithASCII(state bool) table.Option {
+	return func(config *table.Config) {
+		config.ASCII = state
+	}
+}
+
 func TableEx[T any](options ...table.Option) *table.Builder[T] {
 	return table.NewBuilder[T](options...)
 }
@@ -23,3 +29,7 @@ func TableEx[T any](options ...table.Option) *table.Builder[T] {
 func ObjectToTable(obj any, options ...table.Option) *table.Builder[table.FlatObject] {
 	return table.FromObject(obj, options...)
 }
+
+func ObjectToDetail(obj any, options ...table.DetailOption) string {
+	return table.Detail(obj, options...)
+}